@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"apac/internal/app/abuse/usecase"
+	"apac/internal/domain/dto"
+	res "apac/internal/infra/response"
+	"apac/internal/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AbuseHandler struct {
+	Validator    *validator.Validate
+	AbuseUsecase usecase.AbuseUsecaseItf
+}
+
+func NewAbuseHandler(routerGroup fiber.Router, abuseUsecase usecase.AbuseUsecaseItf, m middleware.MiddlewareItf, validator *validator.Validate) {
+	AbuseHandler := AbuseHandler{
+		Validator:    validator,
+		AbuseUsecase: abuseUsecase,
+	}
+
+	routerGroup = routerGroup.Group("/abuse")
+	routerGroup.Get("/rejections", m.Authentication, m.Admin, AbuseHandler.GetRejections)
+}
+
+func (h AbuseHandler) GetRejections(ctx *fiber.Ctx) error {
+	query := new(dto.GetPromptRejectionsRequest)
+	if err := ctx.QueryParser(query); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(query); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	rejections, err := h.AbuseUsecase.GetRejections(query)
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Prompt rejections retrieved successfully", rejections)
+}
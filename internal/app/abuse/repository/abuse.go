@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"apac/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type AbuseRepositoryItf interface {
+	Create(rejection *entity.PromptRejection) error
+	FindAll(reason string, limit int, offset int) ([]entity.PromptRejection, error)
+}
+
+type AbuseRepository struct {
+	db *gorm.DB
+}
+
+func NewAbuseRepository(db *gorm.DB) AbuseRepositoryItf {
+	return &AbuseRepository{db}
+}
+
+func (r *AbuseRepository) Create(rejection *entity.PromptRejection) error {
+	return r.db.Create(rejection).Error
+}
+
+func (r *AbuseRepository) FindAll(reason string, limit int, offset int) ([]entity.PromptRejection, error) {
+	var rejections []entity.PromptRejection
+
+	query := r.db.Order("created_at DESC").Limit(limit).Offset(offset)
+	if reason != "" {
+		query = query.Where("reason = ?", reason)
+	}
+
+	if err := query.Find(&rejections).Error; err != nil {
+		return nil, err
+	}
+
+	return rejections, nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"apac/internal/app/abuse/repository"
+	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	"apac/internal/infra/guard"
+	res "apac/internal/infra/response"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const defaultRejectionLimit = 50
+
+type AbuseUsecaseItf interface {
+	Screen(userId uuid.UUID, source string, places []string, texts ...string) *res.Err
+	GetRejections(query *dto.GetPromptRejectionsRequest) ([]dto.PromptRejectionResponse, *res.Err)
+}
+
+type AbuseUsecase struct {
+	guard           guard.GuardItf
+	abuseRepository repository.AbuseRepositoryItf
+}
+
+func NewAbuseUsecase(guard guard.GuardItf, abuseRepository repository.AbuseRepositoryItf) AbuseUsecaseItf {
+	return &AbuseUsecase{
+		guard:           guard,
+		abuseRepository: abuseRepository,
+	}
+}
+
+// Screen runs the prompt guard over the user-provided places and texts of a
+// request. A rejection is stored for review and returned as 422 carrying the
+// reason.
+func (uc *AbuseUsecase) Screen(userId uuid.UUID, source string, places []string, texts ...string) *res.Err {
+	rejection := uc.guard.Check(places, texts...)
+	if rejection == nil {
+		return nil
+	}
+
+	err := uc.abuseRepository.Create(&entity.PromptRejection{
+		UserID: userId,
+		Source: source,
+		Reason: rejection.Reason,
+		Detail: rejection.Detail,
+		Input:  strings.Join(append(slices.Clone(places), texts...), "\n"),
+	})
+	if err != nil {
+		log.Println("Error: failed to record prompt rejection:", err)
+	}
+
+	rerr := res.ErrUnprocessableEntity(rejection.Detail)
+	rerr.Payload = rejection
+	return rerr
+}
+
+func (uc *AbuseUsecase) GetRejections(query *dto.GetPromptRejectionsRequest) ([]dto.PromptRejectionResponse, *res.Err) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultRejectionLimit
+	}
+
+	rejections, err := uc.abuseRepository.FindAll(query.Reason, limit, query.Offset)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find prompt rejections")
+	}
+
+	resps := make([]dto.PromptRejectionResponse, 0, len(rejections))
+	for _, rejection := range rejections {
+		resps = append(resps, rejection.ParseDTOGet())
+	}
+
+	return resps, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	authRepository "apac/internal/app/auth/repository"
+	preference "apac/internal/app/preference/usecase"
 	userRepository "apac/internal/app/user/repository"
 	"apac/internal/domain/dto"
 	"apac/internal/domain/entity"
@@ -35,14 +36,15 @@ type AuthUsecaseItf interface {
 }
 
 type AuthUsecase struct {
-	authRepository authRepository.AuthRepositoryItf
-	userRepository userRepository.UserRepositoryItf
-	jwt            jwt.JWTItf
-	db             *gorm.DB
-	redis          redis.RedisItf
-	email          email.EmailItf
-	env            *env.Env
-	oauth          oauth.OAuthItf
+	authRepository    authRepository.AuthRepositoryItf
+	userRepository    userRepository.UserRepositoryItf
+	preferenceUsecase preference.PreferenceUsecaseItf
+	jwt               jwt.JWTItf
+	db                *gorm.DB
+	redis             redis.RedisItf
+	email             email.EmailItf
+	env               *env.Env
+	oauth             oauth.OAuthItf
 }
 
 func NewAuthUsecase(
@@ -51,19 +53,21 @@ func NewAuthUsecase(
 	redis redis.RedisItf,
 	authRepository authRepository.AuthRepositoryItf,
 	userRepository userRepository.UserRepositoryItf,
+	preferenceUsecase preference.PreferenceUsecaseItf,
 	jwt jwt.JWTItf,
 	email email.EmailItf,
 	oauth oauth.OAuthItf,
 ) AuthUsecaseItf {
 	return &AuthUsecase{
-		authRepository: authRepository,
-		userRepository: userRepository,
-		jwt:            jwt,
-		redis:          redis,
-		db:             db,
-		email:          email,
-		env:            env,
-		oauth:          oauth,
+		authRepository:    authRepository,
+		userRepository:    userRepository,
+		preferenceUsecase: preferenceUsecase,
+		jwt:               jwt,
+		redis:             redis,
+		db:                db,
+		email:             email,
+		env:               env,
+		oauth:             oauth,
 	}
 }
 
@@ -154,7 +158,7 @@ func (uc *AuthUsecase) VerifyOTP(payload *dto.VerifyOTPRequest) (string, string,
 		return "", "", res.ErrInternalServer("Failed to update user")
 	}
 
-	accessToken, err := uc.jwt.GenerateAccessToken(user.ID, user.Name, user.Email)
+	accessToken, err := uc.jwt.GenerateAccessToken(user.ID, user.Name, user.Email, user.Role)
 	if err != nil {
 		return "", "", res.ErrInternalServer("Failed to generate access token")
 	}
@@ -200,7 +204,7 @@ func (uc *AuthUsecase) Login(payload *dto.LoginRequest) (string, string, *res.Er
 		return "", "", res.ErrInternalServer("Failed to add refresh token")
 	}
 
-	accessToken, err := uc.jwt.GenerateAccessToken(user.ID, user.Name, user.Email)
+	accessToken, err := uc.jwt.GenerateAccessToken(user.ID, user.Name, user.Email, user.Role)
 	if err != nil {
 		return "", "", res.ErrInternalServer("Failed to generate access token")
 	}
@@ -235,7 +239,7 @@ func (uc *AuthUsecase) RefreshToken(payload *dto.RefreshToken) (string, string,
 		return "", "", res.ErrInternalServer("Failed to add refresh token")
 	}
 
-	accessToken, err := uc.jwt.GenerateAccessToken(user.ID, user.Name, user.Email)
+	accessToken, err := uc.jwt.GenerateAccessToken(user.ID, user.Name, user.Email, user.Role)
 	if err != nil {
 		return "", "", res.ErrInternalServer("Failed to generate access token")
 	}
@@ -367,7 +371,7 @@ func (uc *AuthUsecase) GoogleCallback(payload *dto.GoogleCallbackRequest) (strin
 		return "", "", false, res.ErrInternalServer("Failed to add refresh token")
 	}
 
-	accessToken, err := uc.jwt.GenerateAccessToken(user.ID, user.Name, user.Email)
+	accessToken, err := uc.jwt.GenerateAccessToken(user.ID, user.Name, user.Email, user.Role)
 	if err != nil {
 		return "", "", false, res.ErrInternalServer("Failed to generate access token")
 	}
@@ -386,11 +390,13 @@ func (uc *AuthUsecase) ChoosePreference(payload *dto.ChoosePreferenceRequest) *r
 	}
 
 	if payload.Preferences != nil {
-		uc.userRepository.RemoveAllPreferences(user.ID)
-		for _, pref := range payload.Preferences {
-			if err := uc.userRepository.AddPreference(user.ID, pref); err != nil {
-				return res.ErrInternalServer("Failed to add preference")
-			}
+		preferences, rerr := uc.preferenceUsecase.Resolve(user.ID, payload.Preferences)
+		if rerr != nil {
+			return rerr
+		}
+
+		if err := uc.userRepository.ReplacePreferences(user.ID, preferences); err != nil {
+			return res.ErrInternalServer("Failed to update preferences")
 		}
 	}
 
@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"apac/internal/app/currency/usecase"
+	"apac/internal/domain/dto"
+	res "apac/internal/infra/response"
+	"apac/internal/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CurrencyHandler struct {
+	Validator       *validator.Validate
+	CurrencyUsecase usecase.CurrencyUsecaseItf
+}
+
+func NewCurrencyHandler(routerGroup fiber.Router, currencyUsecase usecase.CurrencyUsecaseItf, m middleware.MiddlewareItf, validator *validator.Validate) {
+	CurrencyHandler := CurrencyHandler{
+		Validator:       validator,
+		CurrencyUsecase: currencyUsecase,
+	}
+
+	routerGroup = routerGroup.Group("/exchange-rates")
+	routerGroup.Get("/", m.Authentication, CurrencyHandler.GetRates)
+	routerGroup.Put("/", m.Authentication, m.Admin, CurrencyHandler.UpdateRates)
+}
+
+func (h CurrencyHandler) GetRates(ctx *fiber.Ctx) error {
+	rates, err := h.CurrencyUsecase.GetRates()
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Exchange rates retrieved successfully", rates)
+}
+
+func (h CurrencyHandler) UpdateRates(ctx *fiber.Ctx) error {
+	payload := new(dto.UpdateExchangeRatesRequest)
+	if err := ctx.BodyParser(payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	rates, err := h.CurrencyUsecase.UpdateRates(payload)
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Exchange rates updated successfully", rates)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"apac/internal/domain/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CurrencyRepositoryItf interface {
+	FindAll() ([]entity.ExchangeRate, error)
+	Save(rates []entity.ExchangeRate) error
+	Count() (int64, error)
+}
+
+type CurrencyRepository struct {
+	db *gorm.DB
+}
+
+func NewCurrencyRepository(db *gorm.DB) CurrencyRepositoryItf {
+	return &CurrencyRepository{db}
+}
+
+func (r *CurrencyRepository) FindAll() ([]entity.ExchangeRate, error) {
+	var rates []entity.ExchangeRate
+	if err := r.db.Order("currency ASC").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// Save inserts rates, overwriting the rate of currencies already listed.
+func (r *CurrencyRepository) Save(rates []entity.ExchangeRate) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(&rates).Error
+}
+
+func (r *CurrencyRepository) Count() (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.ExchangeRate{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
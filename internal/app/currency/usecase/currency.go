@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"apac/internal/app/currency/repository"
+	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	"apac/internal/infra/cost"
+	res "apac/internal/infra/response"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+)
+
+type CurrencyUsecaseItf interface {
+	Rates() (cost.Rates, error)
+	GetRates() (*dto.ExchangeRatesResponse, *res.Err)
+	UpdateRates(payload *dto.UpdateExchangeRatesRequest) (*dto.ExchangeRatesResponse, *res.Err)
+	SeedRates(path string) error
+}
+
+type CurrencyUsecase struct {
+	currencyRepository repository.CurrencyRepositoryItf
+}
+
+func NewCurrencyUsecase(currencyRepository repository.CurrencyRepositoryItf) CurrencyUsecaseItf {
+	return &CurrencyUsecase{
+		currencyRepository: currencyRepository,
+	}
+}
+
+// Rates returns the exchange-rate table for cost conversion.
+func (uc *CurrencyUsecase) Rates() (cost.Rates, error) {
+	rates, err := uc.currencyRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	table := make(cost.Rates, len(rates))
+	for _, rate := range rates {
+		table[rate.Currency] = rate.Rate
+	}
+
+	return table, nil
+}
+
+func (uc *CurrencyUsecase) GetRates() (*dto.ExchangeRatesResponse, *res.Err) {
+	rates, err := uc.currencyRepository.FindAll()
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find exchange rates")
+	}
+
+	resp := &dto.ExchangeRatesResponse{
+		Base:  cost.BaseCurrency,
+		Rates: make(map[string]float64, len(rates)),
+	}
+
+	for _, rate := range rates {
+		resp.Rates[rate.Currency] = rate.Rate
+		if rate.UpdatedAt != nil && (resp.UpdatedAt == nil || rate.UpdatedAt.After(*resp.UpdatedAt)) {
+			resp.UpdatedAt = rate.UpdatedAt
+		}
+	}
+
+	return resp, nil
+}
+
+func (uc *CurrencyUsecase) UpdateRates(payload *dto.UpdateExchangeRatesRequest) (*dto.ExchangeRatesResponse, *res.Err) {
+	if payload.Base != "" && !strings.EqualFold(payload.Base, cost.BaseCurrency) {
+		return nil, res.ErrBadRequest("Exchange rates must be quoted against " + cost.BaseCurrency)
+	}
+
+	if rate, ok := payload.Rates[cost.BaseCurrency]; ok && rate != 1 {
+		return nil, res.ErrBadRequest("The rate of " + cost.BaseCurrency + " must be 1")
+	}
+
+	now := time.Now()
+	rates := make([]entity.ExchangeRate, 0, len(payload.Rates))
+	for currency, rate := range payload.Rates {
+		rates = append(rates, entity.ExchangeRate{
+			Currency:  strings.ToUpper(currency),
+			Rate:      rate,
+			UpdatedAt: &now,
+		})
+	}
+
+	if err := uc.currencyRepository.Save(rates); err != nil {
+		return nil, res.ErrInternalServer("Failed to save exchange rates")
+	}
+
+	return uc.GetRates()
+}
+
+// SeedRates imports the rate file at path when the table is still empty.
+func (uc *CurrencyUsecase) SeedRates(path string) error {
+	count, err := uc.currencyRepository.Count()
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	payload := new(dto.UpdateExchangeRatesRequest)
+	if err := json.Unmarshal(content, payload); err != nil {
+		return err
+	}
+
+	if _, rerr := uc.UpdateRates(payload); rerr != nil {
+		return rerr
+	}
+
+	return nil
+}
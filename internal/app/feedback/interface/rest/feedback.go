@@ -0,0 +1,105 @@
+package rest
+
+import (
+	"apac/internal/app/feedback/usecase"
+	"apac/internal/domain/dto"
+	res "apac/internal/infra/response"
+	"apac/internal/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type FeedbackHandler struct {
+	Validator       *validator.Validate
+	FeedbackUsecase usecase.FeedbackUsecaseItf
+}
+
+func NewFeedbackHandler(routerGroup fiber.Router, feedbackUsecase usecase.FeedbackUsecaseItf, m middleware.MiddlewareItf, validator *validator.Validate) {
+	FeedbackHandler := FeedbackHandler{
+		Validator:       validator,
+		FeedbackUsecase: feedbackUsecase,
+	}
+
+	routerGroup.Get("/feedback/report", m.Authentication, m.Admin, FeedbackHandler.GetReport)
+	routerGroup.Get("/trips/:id/feedback", m.Authentication, FeedbackHandler.GetFeedback)
+	routerGroup.Post("/trips/:id/feedback", m.Authentication, FeedbackHandler.Rate)
+	routerGroup.Post("/trips/:id/days/:day/feedback", m.Authentication, FeedbackHandler.Rate)
+	routerGroup.Post("/trips/:id/days/:day/activities/:activity/feedback", m.Authentication, FeedbackHandler.Rate)
+}
+
+func (h FeedbackHandler) Rate(ctx *fiber.Ctx) error {
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	payload := new(dto.FeedbackRequest)
+	if err := ctx.BodyParser(&payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if ctx.Params("day") != "" {
+		day, err := ctx.ParamsInt("day")
+		if err != nil {
+			return res.Error(ctx, res.ErrBadRequest("Invalid day index"))
+		}
+		payload.Day = &day
+	}
+
+	if ctx.Params("activity") != "" {
+		activity, err := ctx.ParamsInt("activity")
+		if err != nil {
+			return res.Error(ctx, res.ErrBadRequest("Invalid activity index"))
+		}
+		payload.Activity = &activity
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	feedback, errs := h.FeedbackUsecase.Rate(userID, tripId, payload)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Feedback saved successfully", feedback)
+}
+
+func (h FeedbackHandler) GetFeedback(ctx *fiber.Ctx) error {
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	feedback, errs := h.FeedbackUsecase.GetFeedback(userID, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Feedback retrieved successfully", feedback)
+}
+
+func (h FeedbackHandler) GetReport(ctx *fiber.Ctx) error {
+	query := new(dto.GetFeedbackReportRequest)
+	if err := ctx.QueryParser(query); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(query); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	report, err := h.FeedbackUsecase.GetReport(query)
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Feedback report retrieved successfully", report)
+}
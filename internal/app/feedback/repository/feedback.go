@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"apac/internal/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FeedbackRepositoryItf interface {
+	FindByTrip(userId uuid.UUID, tripId uuid.UUID) ([]entity.TripFeedback, error)
+	FindByUser(userId uuid.UUID) ([]entity.TripFeedback, error)
+	Save(feedback *entity.TripFeedback) error
+	Report(level string, since *time.Time) ([]ReportRow, error)
+	ReportReasons(level string, since *time.Time) ([]ReasonRow, error)
+}
+
+type ReportRow struct {
+	Model         string
+	PromptVersion string
+	Count         int
+	Up            int
+	Down          int
+}
+
+type ReasonRow struct {
+	Model         string
+	PromptVersion string
+	Reason        string
+	Count         int
+}
+
+type FeedbackRepository struct {
+	db *gorm.DB
+}
+
+func NewFeedbackRepository(db *gorm.DB) FeedbackRepositoryItf {
+	return &FeedbackRepository{db}
+}
+
+func (r *FeedbackRepository) FindByTrip(userId uuid.UUID, tripId uuid.UUID) ([]entity.TripFeedback, error) {
+	var feedback []entity.TripFeedback
+
+	err := r.db.Where("user_id = ?", userId).Where("trip_id = ?", tripId).
+		Order("revision DESC, day ASC NULLS FIRST, activity ASC NULLS FIRST").
+		Find(&feedback).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return feedback, nil
+}
+
+func (r *FeedbackRepository) FindByUser(userId uuid.UUID) ([]entity.TripFeedback, error) {
+	var feedback []entity.TripFeedback
+
+	err := r.db.Where("user_id = ?", userId).Order("updated_at DESC").Find(&feedback).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return feedback, nil
+}
+
+// targetColumns match the unique index on the rated target. Day and
+// activity are NULL above their level, so they are compared through COALESCE.
+var targetColumns = []clause.Column{
+	{Name: "user_id"},
+	{Name: "trip_id"},
+	{Name: "revision"},
+	{Name: "COALESCE(day, -1)", Raw: true},
+	{Name: "COALESCE(activity, -1)", Raw: true},
+}
+
+// Save stores the feedback, replacing the user's earlier feedback on the same
+// target of the same revision. feedback is filled in with the stored row, which keeps the ID and
+// creation time of the feedback it replaced.
+func (r *FeedbackRepository) Save(feedback *entity.TripFeedback) error {
+	return r.db.Clauses(
+		clause.OnConflict{
+			Columns:   targetColumns,
+			DoUpdates: clause.AssignmentColumns([]string{"level", "rating", "reasons", "comment", "prompt_version", "model", "updated_at"}),
+		},
+		clause.Returning{},
+	).Create(feedback).Error
+}
+
+func (r *FeedbackRepository) filter(level string, since *time.Time) *gorm.DB {
+	query := r.db.Model(&entity.TripFeedback{})
+	if level != "" {
+		query = query.Where("level = ?", level)
+	}
+
+	if since != nil {
+		query = query.Where("updated_at >= ?", *since)
+	}
+
+	return query
+}
+
+func (r *FeedbackRepository) Report(level string, since *time.Time) ([]ReportRow, error) {
+	var rows []ReportRow
+
+	err := r.filter(level, since).
+		Select(`COALESCE(model, 'unknown') AS model,
+			COALESCE(prompt_version, 'unknown') AS prompt_version,
+			COUNT(*) AS count,
+			COUNT(*) FILTER (WHERE rating > 0) AS up,
+			COUNT(*) FILTER (WHERE rating < 0) AS down`).
+		Group("1, 2").
+		Order("1, 2").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *FeedbackRepository) ReportReasons(level string, since *time.Time) ([]ReasonRow, error) {
+	var rows []ReasonRow
+
+	err := r.filter(level, since).
+		Joins("CROSS JOIN LATERAL jsonb_array_elements_text(reasons) AS reason(value)").
+		Select(`COALESCE(model, 'unknown') AS model,
+			COALESCE(prompt_version, 'unknown') AS prompt_version,
+			reason.value AS reason,
+			COUNT(*) AS count`).
+		Group("1, 2, 3").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
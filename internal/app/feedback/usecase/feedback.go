@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"apac/internal/app/feedback/repository"
+	trepo "apac/internal/app/trip/repository"
+	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	res "apac/internal/infra/response"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FeedbackUsecaseItf interface {
+	Rate(userId uuid.UUID, tripId uuid.UUID, payload *dto.FeedbackRequest) (*dto.FeedbackResponse, *res.Err)
+	GetFeedback(userId uuid.UUID, tripId uuid.UUID) ([]dto.FeedbackResponse, *res.Err)
+	GetReport(query *dto.GetFeedbackReportRequest) ([]dto.FeedbackReportResponse, *res.Err)
+}
+
+type FeedbackUsecase struct {
+	feedbackRepository repository.FeedbackRepositoryItf
+	tripRepository     trepo.TripRepositoryItf
+}
+
+func NewFeedbackUsecase(feedbackRepository repository.FeedbackRepositoryItf, tripRepository trepo.TripRepositoryItf) FeedbackUsecaseItf {
+	return &FeedbackUsecase{
+		feedbackRepository: feedbackRepository,
+		tripRepository:     tripRepository,
+	}
+}
+
+// Rate records the user's feedback on a trip, day or activity of the trip's
+// current revision. Rating the same target again replaces the earlier
+// feedback; after a refine or regenerate the indexes may point at different
+// days and activities, so the new revision is rated afresh.
+func (uc *FeedbackUsecase) Rate(userId uuid.UUID, tripId uuid.UUID, payload *dto.FeedbackRequest) (*dto.FeedbackResponse, *res.Err) {
+	trip, err := uc.tripRepository.FindById(userId, tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip")
+	}
+
+	if trip == nil {
+		return nil, res.ErrNotFound("Trip not found")
+	}
+
+	level, rerr := target(trip.ParseDTOGet(), payload)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	reasons, err := json.Marshal(append([]string{}, payload.Reasons...))
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to save feedback")
+	}
+
+	feedback := &entity.TripFeedback{
+		TripID:        &tripId,
+		UserID:        userId,
+		Level:         level,
+		Day:           payload.Day,
+		Activity:      payload.Activity,
+		Revision:      trip.Revision,
+		Rating:        payload.Rating,
+		Reasons:       string(reasons),
+		PromptVersion: trip.PromptVersion,
+		Model:         trip.Model,
+	}
+	if payload.Comment != "" {
+		feedback.Comment = &payload.Comment
+	}
+
+	if err := uc.feedbackRepository.Save(feedback); err != nil {
+		return nil, res.ErrInternalServer("Failed to save feedback")
+	}
+
+	resp := feedback.ParseDTOGet()
+	return &resp, nil
+}
+
+// target checks that the addressed day or activity exists and returns the
+// feedback level.
+func target(trip map[string]interface{}, payload *dto.FeedbackRequest) (string, *res.Err) {
+	if payload.Day == nil {
+		return entity.FeedbackTrip, nil
+	}
+
+	days, _ := trip["days"].([]interface{})
+	if *payload.Day < 0 || *payload.Day >= len(days) {
+		return "", res.ErrNotFound("Day not found")
+	}
+
+	if payload.Activity == nil {
+		return entity.FeedbackDay, nil
+	}
+
+	day, _ := days[*payload.Day].(map[string]interface{})
+	activities, _ := day["activities"].([]interface{})
+	if *payload.Activity < 0 || *payload.Activity >= len(activities) {
+		return "", res.ErrNotFound("Activity not found")
+	}
+
+	return entity.FeedbackActivity, nil
+}
+
+func (uc *FeedbackUsecase) GetFeedback(userId uuid.UUID, tripId uuid.UUID) ([]dto.FeedbackResponse, *res.Err) {
+	trip, err := uc.tripRepository.FindById(userId, tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip")
+	}
+
+	if trip == nil {
+		return nil, res.ErrNotFound("Trip not found")
+	}
+
+	feedback, err := uc.feedbackRepository.FindByTrip(userId, tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find feedback")
+	}
+
+	resps := make([]dto.FeedbackResponse, 0, len(feedback))
+	for _, f := range feedback {
+		resps = append(resps, f.ParseDTOGet())
+	}
+
+	return resps, nil
+}
+
+// GetReport aggregates ratings by model and prompt template version,
+// optionally limited to one feedback level and to feedback given since a
+// date.
+func (uc *FeedbackUsecase) GetReport(query *dto.GetFeedbackReportRequest) ([]dto.FeedbackReportResponse, *res.Err) {
+	var since *time.Time
+	if query.Since != "" {
+		date, err := time.ParseInLocation(time.DateOnly, query.Since, time.Local)
+		if err != nil {
+			return nil, res.ErrBadRequest("Invalid since date")
+		}
+		since = &date
+	}
+
+	rows, err := uc.feedbackRepository.Report(query.Level, since)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to build feedback report")
+	}
+
+	reasons, err := uc.feedbackRepository.ReportReasons(query.Level, since)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to build feedback report")
+	}
+
+	resps := make([]dto.FeedbackReportResponse, 0, len(rows))
+	index := make(map[string]int, len(rows))
+	for _, row := range rows {
+		index[row.Model+"\x00"+row.PromptVersion] = len(resps)
+		resps = append(resps, dto.FeedbackReportResponse{
+			Model:         row.Model,
+			PromptVersion: row.PromptVersion,
+			Count:         row.Count,
+			Up:            row.Up,
+			Down:          row.Down,
+			Approval:      math.Round(float64(row.Up)/float64(row.Count)*100) / 100,
+			Reasons:       make(map[string]int),
+		})
+	}
+
+	for _, reason := range reasons {
+		if i, ok := index[reason.Model+"\x00"+reason.PromptVersion]; ok {
+			resps[i].Reasons[reason.Reason] = reason.Count
+		}
+	}
+
+	return resps, nil
+}
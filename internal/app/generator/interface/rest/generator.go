@@ -0,0 +1,224 @@
+package rest
+
+import (
+	"apac/internal/app/generator/usecase"
+	"apac/internal/domain/dto"
+	res "apac/internal/infra/response"
+	"apac/internal/middleware"
+	"bufio"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type GeneratorHandler struct {
+	Validator        *validator.Validate
+	GeneratorUsecase usecase.GeneratorUsecaseItf
+}
+
+func NewGeneratorHandler(
+	routerGroup fiber.Router,
+	generatorUsecase usecase.GeneratorUsecaseItf,
+	m middleware.MiddlewareItf,
+	validator *validator.Validate,
+) {
+	generatorHandler := GeneratorHandler{
+		Validator:        validator,
+		GeneratorUsecase: generatorUsecase,
+	}
+
+	// "/gemini" predates provider selection and stays for existing clients.
+	for _, prefix := range []string{"/generate", "/gemini"} {
+		group := routerGroup.Group(prefix, m.Authentication)
+		group.Post("/", generatorHandler.Prompt)
+		group.Post("/stream", generatorHandler.PromptStream)
+		group.Post("/variants", generatorHandler.Variants)
+	}
+
+	routerGroup.Get("/generate/cache", m.Authentication, m.Admin, generatorHandler.CacheStats)
+	routerGroup.Post("/trips/:id/refine", m.Authentication, generatorHandler.Refine)
+	routerGroup.Post("/trips/:id/translate", m.Authentication, generatorHandler.Translate)
+	routerGroup.Post("/trips/:id/days/:day/regenerate", m.Authentication, generatorHandler.Regenerate)
+	routerGroup.Post("/trips/:id/days/:day/activities/:activity/regenerate", m.Authentication, generatorHandler.Regenerate)
+	routerGroup.Post("/trips/:id/days/:day/meals/:meal/regenerate", m.Authentication, generatorHandler.Regenerate)
+}
+
+func (h GeneratorHandler) Prompt(ctx *fiber.Ctx) error {
+	payload := new(dto.GenerateTripRequest)
+	if err := ctx.BodyParser(&payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, err := h.GeneratorUsecase.Prompt(ctx.UserContext(), payload, userID)
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "AI prompt succesful", response)
+}
+
+func (h GeneratorHandler) CacheStats(ctx *fiber.Ctx) error {
+	stats, err := h.GeneratorUsecase.CacheStats()
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Generation cache stats retrieved successfully", stats)
+}
+
+func (h GeneratorHandler) Variants(ctx *fiber.Ctx) error {
+	payload := new(dto.GenerateVariantsRequest)
+	if err := ctx.BodyParser(&payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, err := h.GeneratorUsecase.Variants(ctx.UserContext(), payload, userID)
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Trip variants generated successfully", response)
+}
+
+func (h GeneratorHandler) Refine(ctx *fiber.Ctx) error {
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	payload := new(dto.RefineTripRequest)
+	if err := ctx.BodyParser(&payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, errs := h.GeneratorUsecase.Refine(ctx.UserContext(), payload, userID, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Trip refined successfully", response)
+}
+
+func (h GeneratorHandler) Translate(ctx *fiber.Ctx) error {
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	payload := new(dto.TranslateTripRequest)
+	if err := ctx.BodyParser(&payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, errs := h.GeneratorUsecase.Translate(ctx.UserContext(), payload, userID, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Trip translated successfully", response)
+}
+
+func (h GeneratorHandler) Regenerate(ctx *fiber.Ctx) error {
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	payload := new(dto.RegenerateTripRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&payload); err != nil {
+			return res.BadRequest(ctx)
+		}
+	}
+
+	payload.Day, err = ctx.ParamsInt("day")
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid day index"))
+	}
+
+	if ctx.Params("activity") != "" {
+		activity, err := ctx.ParamsInt("activity")
+		if err != nil {
+			return res.Error(ctx, res.ErrBadRequest("Invalid activity index"))
+		}
+		payload.Activity = &activity
+	}
+
+	payload.Meal = ctx.Params("meal")
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, errs := h.GeneratorUsecase.Regenerate(ctx.UserContext(), payload, userID, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Trip regenerated successfully", response)
+}
+
+func (h GeneratorHandler) PromptStream(ctx *fiber.Ctx) error {
+	payload := new(dto.GenerateTripRequest)
+	if err := ctx.BodyParser(&payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	ctx.Set("X-Accel-Buffering", "no")
+
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		streamCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		emit := func(event string, data any) error {
+			if err := res.WriteEvent(w, event, data); err != nil {
+				cancel()
+				return err
+			}
+			return nil
+		}
+
+		if err := h.GeneratorUsecase.PromptStream(streamCtx, payload, userID, emit); err != nil {
+			emit("error", err)
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"apac/internal/domain/dto"
+	"apac/internal/infra/llm"
+	"apac/internal/infra/prompt"
+	res "apac/internal/infra/response"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	cachePrefix    = "generation:trip:"
+	cacheHitsKey   = "generation:cache:hits"
+	cacheMissesKey = "generation:cache:misses"
+)
+
+// cacheKey identifies requests that should get the same itinerary. It is
+// derived from the prompt built for a normalized copy of the request, so it
+// covers the structured fields and the preference set, and changes whenever
+// the prompt template or the model changes. Requests shaped by a taste
+// profile are not cached. An empty key disables caching for the request.
+func (uc *GeneratorUsecase) cacheKey(payload *dto.GenerateTripRequest, profile *dto.GetProfileResponse) string {
+	normalized := normalizeRequest(payload)
+
+	if profile != nil {
+		sorted := *profile
+		sorted.Preferences = slices.Clone(profile.Preferences)
+		slices.SortFunc(sorted.Preferences, func(a, b dto.PreferenceResponse) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		profile = &sorted
+	}
+
+	input, err := uc.prompt.Trip(normalized, profile, nil)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(llm.Model(uc.env) + "\n" + uc.prompt.Version(prompt.TemplateTrip) + "\n" + input))
+	return cachePrefix + hex.EncodeToString(sum[:])
+}
+
+func normalizeRequest(payload *dto.GenerateTripRequest) *dto.GenerateTripRequest {
+	normalized := *payload
+
+	normalized.Destinations = make([]string, 0, len(payload.Destinations))
+	for _, destination := range payload.Destinations {
+		normalized.Destinations = append(normalized.Destinations, normalizeText(destination))
+	}
+
+	normalized.MustSee = make([]string, 0, len(payload.MustSee))
+	for _, place := range payload.MustSee {
+		normalized.MustSee = append(normalized.MustSee, normalizeText(place))
+	}
+	slices.Sort(normalized.MustSee)
+
+	normalized.TravelerAges = slices.Clone(payload.TravelerAges)
+	slices.Sort(normalized.TravelerAges)
+
+	if normalized.Travelers == 0 && len(normalized.TravelerAges) == 0 {
+		normalized.Travelers = 1
+	}
+
+	if payload.Budget != nil {
+		normalized.Budget = &dto.TripBudget{
+			Amount:   math.Round(payload.Budget.Amount),
+			Currency: strings.ToUpper(payload.Budget.Currency),
+		}
+	}
+
+	normalized.Text = normalizeText(payload.Text)
+
+	return &normalized
+}
+
+func normalizeText(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+// cached returns the itinerary stored under key, or nil on a miss. Cache
+// failures are treated as misses so generation still works without Redis.
+// An empty key means the caller opted out and is not counted.
+func (uc *GeneratorUsecase) cached(key string) map[string]interface{} {
+	if key == "" {
+		return nil
+	}
+
+	content, err := uc.cache.Get(key)
+	if err != nil {
+		log.Println("Error: failed to read generation cache:", err)
+	}
+
+	if err != nil || len(content) == 0 {
+		uc.count(cacheMissesKey)
+		return nil
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(content, &response); err != nil {
+		uc.count(cacheMissesKey)
+		return nil
+	}
+
+	uc.count(cacheHitsKey)
+	return response
+}
+
+func (uc *GeneratorUsecase) store(key string, response map[string]interface{}) {
+	if key == "" {
+		return
+	}
+
+	content, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+
+	if err := uc.cache.Set(key, content, uc.env.GenerationCacheTTL); err != nil {
+		log.Println("Error: failed to write generation cache:", err)
+	}
+}
+
+func (uc *GeneratorUsecase) count(key string) {
+	if _, err := uc.cache.Incr(key); err != nil {
+		log.Println("Error: failed to count generation cache access:", err)
+	}
+}
+
+func (uc *GeneratorUsecase) CacheStats() (*dto.GenerationCacheStatsResponse, *res.Err) {
+	hits, err := uc.counter(cacheHitsKey)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to read generation cache stats")
+	}
+
+	misses, err := uc.counter(cacheMissesKey)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to read generation cache stats")
+	}
+
+	stats := &dto.GenerationCacheStatsResponse{Hits: hits, Misses: misses}
+	if hits+misses > 0 {
+		stats.HitRate = float64(hits) / float64(hits+misses)
+	}
+
+	return stats, nil
+}
+
+func (uc *GeneratorUsecase) counter(key string) (int64, error) {
+	value, err := uc.cache.Get(key)
+	if err != nil || len(value) == 0 {
+		return 0, err
+	}
+
+	return strconv.ParseInt(string(value), 10, 64)
+}
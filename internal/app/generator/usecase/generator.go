@@ -0,0 +1,649 @@
+package usecase
+
+import (
+	abuse "apac/internal/app/abuse/usecase"
+	taste "apac/internal/app/taste/usecase"
+	trepo "apac/internal/app/trip/repository"
+	usage "apac/internal/app/usage/usecase"
+	urepo "apac/internal/app/user/repository"
+	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	"apac/internal/domain/env"
+	"apac/internal/infra/cost"
+	"apac/internal/infra/jsondiff"
+	"apac/internal/infra/llm"
+	"apac/internal/infra/prompt"
+	"apac/internal/infra/redis"
+	res "apac/internal/infra/response"
+	"apac/internal/infra/schema"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxTripDays = 30
+
+type GeneratorUsecaseItf interface {
+	Prompt(ctx context.Context, payload *dto.GenerateTripRequest, userId uuid.UUID) (map[string]interface{}, *res.Err)
+	PromptStream(ctx context.Context, payload *dto.GenerateTripRequest, userId uuid.UUID, emit func(event string, data any) error) *res.Err
+	Refine(ctx context.Context, payload *dto.RefineTripRequest, userId uuid.UUID, tripId uuid.UUID) (*dto.RefineTripResponse, *res.Err)
+	Regenerate(ctx context.Context, payload *dto.RegenerateTripRequest, userId uuid.UUID, tripId uuid.UUID) (*dto.RefineTripResponse, *res.Err)
+	Variants(ctx context.Context, payload *dto.GenerateVariantsRequest, userId uuid.UUID) (*dto.GenerateVariantsResponse, *res.Err)
+	Translate(ctx context.Context, payload *dto.TranslateTripRequest, userId uuid.UUID, tripId uuid.UUID) (map[string]interface{}, *res.Err)
+	CacheStats() (*dto.GenerationCacheStatsResponse, *res.Err)
+}
+
+type GeneratorUsecase struct {
+	env            *env.Env
+	generator      llm.TripGenerator
+	schema         schema.SchemaItf
+	prompt         prompt.PromptItf
+	maxRepairs     int
+	userRepository urepo.UserRepositoryItf
+	tripRepository trepo.TripRepositoryItf
+	usageUsecase   usage.UsageUsecaseItf
+	cache          redis.RedisItf
+	abuseUsecase   abuse.AbuseUsecaseItf
+	tasteUsecase   taste.TasteUsecaseItf
+}
+
+func NewGeneratorUsecase(
+	env *env.Env,
+	generator llm.TripGenerator,
+	schema schema.SchemaItf,
+	prompt prompt.PromptItf,
+	userRepository urepo.UserRepositoryItf,
+	tripRepository trepo.TripRepositoryItf,
+	usageUsecase usage.UsageUsecaseItf,
+	cache redis.RedisItf,
+	abuseUsecase abuse.AbuseUsecaseItf,
+	tasteUsecase taste.TasteUsecaseItf,
+) GeneratorUsecaseItf {
+	return &GeneratorUsecase{
+		env:            env,
+		generator:      generator,
+		schema:         schema,
+		prompt:         prompt,
+		maxRepairs:     env.LLMMaxRepairs,
+		userRepository: userRepository,
+		tripRepository: tripRepository,
+		usageUsecase:   usageUsecase,
+		cache:          cache,
+		abuseUsecase:   abuseUsecase,
+		tasteUsecase:   tasteUsecase,
+	}
+}
+
+func (uc *GeneratorUsecase) Prompt(ctx context.Context, payload *dto.GenerateTripRequest, userId uuid.UUID) (map[string]interface{}, *res.Err) {
+	if rerr := uc.abuseUsecase.Screen(userId, "generate", payload.UserPlaces(), payload.Text); rerr != nil {
+		return nil, rerr
+	}
+
+	input, key, rerr := uc.buildPrompt(payload, userId)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	if cached := uc.cached(key); cached != nil {
+		return uc.saveTrip(uc.newTrip(userId, payload.Language, payload.Budget), cached)
+	}
+
+	if rerr := uc.usageUsecase.Check(userId); rerr != nil {
+		return nil, rerr
+	}
+
+	response, rerr := uc.generate(ctx, userId, input)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	uc.store(key, response)
+
+	return uc.saveTrip(uc.newTrip(userId, payload.Language, payload.Budget), response)
+}
+
+// PromptStream reports generation progress through emit: "progress" while
+// text arrives, "day" for each itinerary day as soon as it is complete and
+// "done" with the persisted trip. A failing emit (the client went away)
+// cancels the upstream call.
+func (uc *GeneratorUsecase) PromptStream(ctx context.Context, payload *dto.GenerateTripRequest, userId uuid.UUID, emit func(event string, data any) error) *res.Err {
+	if rerr := uc.abuseUsecase.Screen(userId, "generate_stream", payload.UserPlaces(), payload.Text); rerr != nil {
+		return rerr
+	}
+
+	input, key, rerr := uc.buildPrompt(payload, userId)
+	if rerr != nil {
+		return rerr
+	}
+
+	if cached := uc.cached(key); cached != nil {
+		return uc.replay(cached, userId, payload, emit)
+	}
+
+	if rerr := uc.usageUsecase.Check(userId); rerr != nil {
+		return rerr
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := emit("progress", map[string]any{"stage": "started"}); err != nil {
+		return nil
+	}
+
+	scanner := newDayScanner()
+	received := 0
+	response, spent, err := uc.generator.GenerateStream(ctx, input, func(chunk string) error {
+		received += len(chunk)
+		if err := emit("progress", map[string]any{"stage": "generating", "received": received}); err != nil {
+			cancel()
+			return err
+		}
+
+		for _, day := range scanner.Feed(chunk) {
+			if err := emit("day", day); err != nil {
+				cancel()
+				return err
+			}
+		}
+
+		return nil
+	})
+	uc.usageUsecase.Record(userId, spent)
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	_, errs, rerr := uc.check(response, err)
+	if rerr != nil {
+		return rerr
+	}
+
+	if len(errs) > 0 {
+		if err := emit("progress", map[string]any{"stage": "repairing", "errors": errs}); err != nil {
+			return nil
+		}
+
+		response, rerr = uc.repair(ctx, userId, input, response, err)
+		if rerr != nil {
+			return rerr
+		}
+	}
+
+	uc.store(key, response)
+
+	if err := emit("progress", map[string]any{"stage": "saving"}); err != nil {
+		return nil
+	}
+
+	trip, rerr := uc.saveTrip(uc.newTrip(userId, payload.Language, payload.Budget), response)
+	if rerr != nil {
+		return rerr
+	}
+
+	emit("done", map[string]any{"id": trip["id"], "trip": trip})
+
+	return nil
+}
+
+// replay answers a stream from the generation cache, emitting the same
+// events a live generation would.
+func (uc *GeneratorUsecase) replay(response map[string]interface{}, userId uuid.UUID, payload *dto.GenerateTripRequest, emit func(event string, data any) error) *res.Err {
+	if err := emit("progress", map[string]any{"stage": "cached"}); err != nil {
+		return nil
+	}
+
+	days, _ := response["days"].([]interface{})
+	for _, day := range days {
+		if err := emit("day", day); err != nil {
+			return nil
+		}
+	}
+
+	trip, rerr := uc.saveTrip(uc.newTrip(userId, payload.Language, payload.Budget), response)
+	if rerr != nil {
+		return rerr
+	}
+
+	emit("done", map[string]any{"id": trip["id"], "trip": trip})
+
+	return nil
+}
+
+// Refine applies a free-form instruction to an existing trip. The previous
+// instructions for the trip are sent along as conversation history, and the
+// result is stored as the trip's next revision.
+func (uc *GeneratorUsecase) Refine(ctx context.Context, payload *dto.RefineTripRequest, userId uuid.UUID, tripId uuid.UUID) (*dto.RefineTripResponse, *res.Err) {
+	if rerr := uc.abuseUsecase.Screen(userId, "refine", nil, payload.Instruction); rerr != nil {
+		return nil, rerr
+	}
+
+	if rerr := uc.usageUsecase.Check(userId); rerr != nil {
+		return nil, rerr
+	}
+
+	trip, err := uc.tripRepository.FindById(userId, tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip")
+	}
+
+	if trip == nil {
+		return nil, res.ErrNotFound("Trip not found")
+	}
+
+	messages, err := uc.tripRepository.FindMessages(tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip conversation")
+	}
+
+	history := make([]string, 0)
+	for _, message := range messages {
+		if message.Role == entity.MessageUser {
+			history = append(history, message.Content)
+		}
+	}
+
+	input, err := uc.prompt.Refine(trip.Content, history, payload.Instruction)
+	if err != nil {
+		return nil, promptError(err)
+	}
+
+	response, rerr := uc.generate(ctx, userId, input)
+	if rerr != nil {
+		return nil, rerr
+	}
+	delete(response, "id")
+
+	return uc.saveRevision(trip, response, payload.Instruction, uc.prompt.Version(prompt.TemplateRefine))
+}
+
+// Regenerate replaces a single day, or one activity or meal within that day,
+// while every other part of the trip is kept as it is. The model is shown
+// the whole trip for context, but only the targeted fragment of its answer
+// is copied into the stored itinerary.
+func (uc *GeneratorUsecase) Regenerate(ctx context.Context, payload *dto.RegenerateTripRequest, userId uuid.UUID, tripId uuid.UUID) (*dto.RefineTripResponse, *res.Err) {
+	if rerr := uc.abuseUsecase.Screen(userId, "regenerate", nil, payload.Instruction); rerr != nil {
+		return nil, rerr
+	}
+
+	if rerr := uc.usageUsecase.Check(userId); rerr != nil {
+		return nil, rerr
+	}
+
+	trip, err := uc.tripRepository.FindById(userId, tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip")
+	}
+
+	if trip == nil {
+		return nil, res.ErrNotFound("Trip not found")
+	}
+
+	current := trip.ParseDTOGet()
+	target, rerr := findTarget(current, payload)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	input, err := uc.prompt.Regenerate(trip.Content, payload.Path(), payload.Instruction)
+	if err != nil {
+		return nil, promptError(err)
+	}
+
+	response, rerr := uc.generate(ctx, userId, input)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	replacement, rerr := findTarget(response, payload)
+	if rerr != nil {
+		log.Printf("Regenerated trip is missing %s\n", payload.Path())
+		return nil, res.ErrBadGateway("AI response did not include the regenerated item")
+	}
+
+	if payload.Activity == nil && payload.Meal == "" {
+		// A day keeps its place in the calendar.
+		replacement["day"] = target["day"]
+		replacement["date"] = target["date"]
+	}
+
+	updated := trip.ParseDTOGet()
+	days := updated["days"].([]interface{})
+	day := days[payload.Day].(map[string]interface{})
+	switch {
+	case payload.Activity != nil:
+		day["activities"].([]interface{})[*payload.Activity] = replacement
+	case payload.Meal != "":
+		day["meals"].(map[string]interface{})[payload.Meal] = replacement
+	default:
+		days[payload.Day] = replacement
+	}
+
+	instruction := "Regenerate " + payload.Path()
+	if payload.Instruction != "" {
+		instruction += ": " + payload.Instruction
+	}
+
+	return uc.saveRevision(trip, updated, instruction, uc.prompt.Version(prompt.TemplateRegenerate))
+}
+
+// findTarget returns the object addressed by payload inside trip.
+func findTarget(trip map[string]interface{}, payload *dto.RegenerateTripRequest) (map[string]interface{}, *res.Err) {
+	days, _ := trip["days"].([]interface{})
+	if payload.Day < 0 || payload.Day >= len(days) {
+		return nil, res.ErrNotFound("Day not found")
+	}
+
+	day, ok := days[payload.Day].(map[string]interface{})
+	if !ok {
+		return nil, res.ErrNotFound("Day not found")
+	}
+
+	switch {
+	case payload.Activity != nil:
+		activities, _ := day["activities"].([]interface{})
+		if *payload.Activity < 0 || *payload.Activity >= len(activities) {
+			return nil, res.ErrNotFound("Activity not found")
+		}
+
+		activity, ok := activities[*payload.Activity].(map[string]interface{})
+		if !ok {
+			return nil, res.ErrNotFound("Activity not found")
+		}
+
+		return activity, nil
+	case payload.Meal != "":
+		meals, _ := day["meals"].(map[string]interface{})
+		meal, ok := meals[payload.Meal].(map[string]interface{})
+		if !ok {
+			return nil, res.ErrNotFound("Meal not found")
+		}
+
+		return meal, nil
+	default:
+		return day, nil
+	}
+}
+
+// saveRevision stores updated as the trip's next revision, records the
+// instruction and outcome in the trip's conversation and reports what
+// changed compared to the current revision.
+func (uc *GeneratorUsecase) saveRevision(trip *entity.Trip, updated map[string]interface{}, instruction string, version string) (*dto.RefineTripResponse, *res.Err) {
+	diff := jsondiff.Diff(trip.ParseDTOGet(), updated)
+	changes := make([]dto.TripChangeResponse, 0, len(diff))
+	for _, change := range diff {
+		changes = append(changes, dto.TripChangeResponse{
+			Path:   change.Path,
+			Op:     change.Op,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	previous := trip.Content
+	if err := trip.SetContent(updated, cost.Extract(updated)); err != nil {
+		return nil, res.ErrInternalServer("Unable to parse JSON response into string")
+	}
+
+	revision := &entity.TripRevision{
+		TripID:        trip.ID,
+		Number:        trip.Revision + 1,
+		Content:       trip.Content,
+		Instruction:   &instruction,
+		PromptVersion: &version,
+	}
+
+	err := uc.tripRepository.SaveRevision(trip, previous, revision, []entity.TripMessage{
+		{TripID: trip.ID, Role: entity.MessageUser, Content: instruction, Revision: revision.Number},
+		{TripID: trip.ID, Role: entity.MessageAssistant, Content: fmt.Sprintf("Saved revision %d with %d changes.", revision.Number, len(changes)), Revision: revision.Number},
+	})
+	if errors.Is(err, trepo.ErrRevisionConflict) {
+		return nil, res.ErrConflict("Trip was changed in the meantime, please try again")
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to save trip revision")
+	}
+
+	return &dto.RefineTripResponse{
+		ID:       trip.ID.String(),
+		Revision: revision.Number,
+		Trip:     updated,
+		Changes:  changes,
+	}, nil
+}
+
+func (uc *GeneratorUsecase) generate(ctx context.Context, userId uuid.UUID, prompt string) (map[string]interface{}, *res.Err) {
+	response, spent, err := uc.generator.Generate(ctx, prompt)
+	uc.usageUsecase.Record(userId, spent)
+
+	return uc.repair(ctx, userId, prompt, response, err)
+}
+
+// promptError logs a template failure, which means a broken prompt file
+// rather than bad user input.
+func promptError(err error) *res.Err {
+	log.Println("Error: failed to render prompt template:", err)
+	return res.ErrInternalServer("Failed to build AI prompt")
+}
+
+// aiError logs the provider error and turns it into a message that is safe
+// to show to users.
+func aiError(err error) *res.Err {
+	log.Println("Error: AI provider call failed:", err)
+
+	switch {
+	case errors.Is(err, llm.ErrCircuitOpen):
+		return res.ErrServiceUnavailable("AI service is temporarily unavailable, please try again later")
+	case errors.Is(err, context.DeadlineExceeded):
+		return res.ErrGatewayTimeout("AI service took too long to respond, please try again")
+	case llm.StatusCode(err) == http.StatusTooManyRequests:
+		return res.ErrServiceUnavailable("AI service is busy, please try again later")
+	default:
+		return res.ErrBadGateway("AI service failed to generate a trip")
+	}
+}
+
+// repair takes the result of a generation call and, while the response is
+// invalid or was not valid JSON at all, asks the model to fix its own output
+// by re-prompting with the errors, up to maxRepairs times.
+func (uc *GeneratorUsecase) repair(ctx context.Context, userId uuid.UUID, prompt string, response map[string]interface{}, err error) (map[string]interface{}, *res.Err) {
+	for attempt := 0; ; attempt++ {
+		previous, errs, rerr := uc.check(response, err)
+		if rerr != nil {
+			return nil, rerr
+		}
+
+		if len(errs) == 0 {
+			return response, nil
+		}
+
+		if attempt >= uc.maxRepairs {
+			log.Printf("AI response still invalid after %d repairs: %s\n", attempt, strings.Join(errs, "; "))
+			return nil, res.ErrBadGateway("AI response failed validation")
+		}
+
+		input, perr := uc.prompt.Repair(prompt, previous, errs)
+		if perr != nil {
+			return nil, promptError(perr)
+		}
+
+		var spent llm.Usage
+		response, spent, err = uc.generator.Generate(ctx, input)
+		uc.usageUsecase.Record(userId, spent)
+	}
+}
+
+// check returns the errors to repair in the result of a generation call,
+// along with the answer to show the model. An answer that is not valid JSON
+// is shown as the raw text; any other failed call cannot be repaired.
+func (uc *GeneratorUsecase) check(response map[string]interface{}, err error) (string, []string, *res.Err) {
+	var decodeErr *llm.DecodeError
+	if errors.As(err, &decodeErr) {
+		return decodeErr.Text, []string{"$: " + decodeErr.Error()}, nil
+	}
+
+	if err != nil {
+		return "", nil, aiError(err)
+	}
+
+	errs := uc.schema.Validate(response)
+	if len(errs) == 0 {
+		return "", nil, nil
+	}
+
+	previous, err := json.Marshal(response)
+	if err != nil {
+		return "", nil, res.ErrInternalServer("Unable to parse JSON response into string")
+	}
+
+	return string(previous), errs, nil
+}
+
+// buildPrompt checks the requested dates and renders the structured request.
+// The traveller's profile, preferences and taste profile are only included
+// when the request asks for them. A missing language is filled in from the
+// profile. The returned cache key is empty when the request opted out of the
+// generation cache or is shaped by the user's own taste profile, which no
+// other request shares.
+func (uc *GeneratorUsecase) buildPrompt(payload *dto.GenerateTripRequest, userId uuid.UUID) (string, string, *res.Err) {
+	if payload.StartDate != "" {
+		start, err := time.Parse(time.DateOnly, payload.StartDate)
+		if err != nil {
+			return "", "", res.ErrBadRequest("Invalid start date")
+		}
+
+		end, err := time.Parse(time.DateOnly, payload.EndDate)
+		if err != nil {
+			return "", "", res.ErrBadRequest("Invalid end date")
+		}
+
+		if end.Before(start) {
+			return "", "", res.ErrBadRequest("End date must not be before start date")
+		}
+
+		if end.Sub(start).Hours()/24+1 > maxTripDays {
+			return "", "", res.ErrBadRequest("Trips can last at most 30 days")
+		}
+	}
+
+	profile, rerr := uc.profile(userId)
+	if rerr != nil {
+		return "", "", rerr
+	}
+
+	// The profile language applies even when preferences are switched off.
+	if payload.Language == "" {
+		payload.Language = profile.Language
+	}
+
+	var tasteProfile *dto.TasteProfileResponse
+	if payload.PreferencesEnabled() {
+		tasteProfile, rerr = uc.taste(userId)
+		if rerr != nil {
+			return "", "", rerr
+		}
+	} else {
+		profile = nil
+	}
+
+	var key string
+	if !payload.NoCache && tasteProfile == nil {
+		key = uc.cacheKey(payload, profile)
+	}
+
+	input, err := uc.prompt.Trip(payload, profile, tasteProfile)
+	if err != nil {
+		return "", "", promptError(err)
+	}
+
+	return input, key, nil
+}
+
+// taste returns what past trips and feedback say about the user, or nil
+// when there is nothing to go on yet.
+func (uc *GeneratorUsecase) taste(userId uuid.UUID) (*dto.TasteProfileResponse, *res.Err) {
+	if userId == uuid.Nil {
+		return nil, nil
+	}
+
+	tasteProfile, rerr := uc.tasteUsecase.GetProfile(userId)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	if tasteProfile.Empty() {
+		return nil, nil
+	}
+
+	return tasteProfile, nil
+}
+
+func (uc *GeneratorUsecase) profile(userId uuid.UUID) (*dto.GetProfileResponse, *res.Err) {
+	if userId == uuid.Nil {
+		return nil, res.ErrBadRequest("User ID is required to create a trip")
+	}
+
+	user, err := uc.userRepository.FindById(userId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find user")
+	}
+
+	if user == nil {
+		return nil, res.ErrNotFound("User not found")
+	}
+
+	profile := user.ParseDTOGet()
+
+	return &profile, nil
+}
+
+// newTrip prepares a trip owned by userId, recording the template version
+// and model its content is generated with, its language and the budget it
+// has to fit.
+func (uc *GeneratorUsecase) newTrip(userId uuid.UUID, language string, budget *dto.TripBudget) *entity.Trip {
+	version := uc.prompt.Version(prompt.TemplateTrip)
+	model := llm.Model(uc.env)
+	trip := &entity.Trip{
+		UserID:        userId,
+		PromptVersion: &version,
+		Model:         &model,
+	}
+
+	if language != "" {
+		trip.Language = &language
+	}
+
+	if budget != nil {
+		currency := strings.ToUpper(budget.Currency)
+		trip.BudgetAmount = &budget.Amount
+		trip.BudgetCurrency = &currency
+	}
+
+	return trip
+}
+
+// saveTrip stores response as the content of trip, which carries the owner
+// and any other attributes the caller wants persisted with it.
+func (uc *GeneratorUsecase) saveTrip(trip *entity.Trip, response map[string]interface{}) (map[string]interface{}, *res.Err) {
+	if err := trip.SetContent(response, cost.Extract(response)); err != nil {
+		return nil, res.ErrInternalServer("Unable to parse JSON response into string")
+	}
+
+	trip, err := uc.tripRepository.Create(trip)
+	if err != nil {
+		log.Println("Error: failed to save trip:", err)
+		return nil, res.ErrInternalServer("Cannot add trip to history")
+	}
+
+	response["id"] = trip.ID
+
+	return response, nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	usage "apac/internal/app/usage/usecase"
+	"apac/internal/infra/llm"
+	"apac/internal/infra/prompt"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// titleSchema only requires a title, which is all the repair loop needs to
+// tell a valid trip from an invalid one.
+type titleSchema struct{}
+
+func (titleSchema) Validate(trip map[string]interface{}) []string {
+	if _, ok := trip["title"]; !ok {
+		return []string{"$.title: is required"}
+	}
+
+	return nil
+}
+
+type repairPrompt struct {
+	prompt.PromptItf
+}
+
+func (repairPrompt) Repair(prompt string, previous string, errs []string) (string, error) {
+	return prompt + "\n" + strings.Join(errs, "\n"), nil
+}
+
+// scriptedGenerator answers each call with the next trip in responses and
+// remembers the prompts it was given.
+type scriptedGenerator struct {
+	responses []map[string]interface{}
+	prompts   []string
+}
+
+func (g *scriptedGenerator) Generate(ctx context.Context, prompt string) (map[string]interface{}, llm.Usage, error) {
+	g.prompts = append(g.prompts, prompt)
+	response := g.responses[0]
+	g.responses = g.responses[1:]
+	return response, llm.Usage{}, nil
+}
+
+func (g *scriptedGenerator) GenerateStream(ctx context.Context, prompt string, onChunk func(string) error) (map[string]interface{}, llm.Usage, error) {
+	return g.Generate(ctx, prompt)
+}
+
+type countingUsage struct {
+	usage.UsageUsecaseItf
+	records int
+}
+
+func (u *countingUsage) Record(userId uuid.UUID, spent llm.Usage) {
+	u.records++
+}
+
+func TestRepair(t *testing.T) {
+	valid := map[string]interface{}{"title": "Bali"}
+	invalid := map[string]interface{}{"summary": "Beaches"}
+
+	truncated := &llm.DecodeError{Text: `{"title": "Ba`, Err: errors.New("unexpected end of JSON input")}
+
+	tests := []struct {
+		name      string
+		response  map[string]interface{}
+		err       error
+		responses []map[string]interface{}
+		calls     int
+		code      int
+		want      string
+	}{
+		{
+			name:     "valid response is kept",
+			response: valid,
+		},
+		{
+			name:      "invalid response is repaired",
+			response:  invalid,
+			responses: []map[string]interface{}{invalid, valid},
+			calls:     2,
+			want:      "$.title: is required",
+		},
+		{
+			name:      "gives up after the repair limit",
+			response:  invalid,
+			responses: []map[string]interface{}{invalid, invalid},
+			calls:     2,
+			code:      http.StatusBadGateway,
+			want:      "$.title: is required",
+		},
+		{
+			name:      "truncated response is repaired from its text",
+			err:       truncated,
+			responses: []map[string]interface{}{valid},
+			calls:     1,
+			want:      "$: response is not valid JSON: unexpected end of JSON input",
+		},
+		{
+			name: "failed call is not repaired",
+			err:  &llm.APIError{Provider: "gemini", StatusCode: http.StatusInternalServerError},
+			code: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &scriptedGenerator{responses: tt.responses}
+			recorder := &countingUsage{}
+			uc := &GeneratorUsecase{
+				generator:    generator,
+				schema:       titleSchema{},
+				prompt:       repairPrompt{},
+				usageUsecase: recorder,
+				maxRepairs:   2,
+			}
+
+			got, rerr := uc.repair(context.Background(), uuid.Nil, "prompt", tt.response, tt.err)
+			switch {
+			case tt.code != 0 && (rerr == nil || rerr.Code != tt.code):
+				t.Errorf("repair() error = %v, want code %d", rerr, tt.code)
+			case tt.code == 0 && (rerr != nil || got["title"] != "Bali"):
+				t.Errorf("repair() = %v, %v, want the valid trip", got, rerr)
+			}
+
+			if len(generator.prompts) != tt.calls || recorder.records != tt.calls {
+				t.Errorf("made %d calls and recorded %d, want %d", len(generator.prompts), recorder.records, tt.calls)
+			}
+
+			for _, prompt := range generator.prompts {
+				if !strings.Contains(prompt, tt.want) {
+					t.Errorf("repair prompt does not list the validation errors:\n%s", prompt)
+				}
+			}
+		})
+	}
+}
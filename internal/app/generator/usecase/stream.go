@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"encoding/json"
+)
+
+// dayScanner picks finished entries of the top-level "days" array out of a
+// JSON document that is still being streamed. It keeps its position between
+// chunks, so every byte is scanned once.
+type dayScanner struct {
+	// buf holds the text from the start of the day or string being read;
+	// everything before it has been scanned and is no longer needed.
+	buf       []byte
+	pos       int
+	depth     int
+	inString  bool
+	escaped   bool
+	strStart  int
+	lastKey   string
+	daysDepth int
+	dayStart  int
+}
+
+func newDayScanner() *dayScanner {
+	return &dayScanner{daysDepth: -1, dayStart: -1}
+}
+
+func (s *dayScanner) Feed(chunk string) []map[string]interface{} {
+	raws := s.scan(chunk)
+	if len(raws) == 0 {
+		return nil
+	}
+
+	days := make([]map[string]interface{}, 0, len(raws))
+	for _, raw := range raws {
+		var day map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &day); err == nil {
+			days = append(days, day)
+		}
+	}
+
+	return days
+}
+
+// scan reads chunk and returns the days it completed.
+func (s *dayScanner) scan(chunk string) []string {
+	s.buf = append(s.buf, chunk...)
+
+	var days []string
+	for ; s.pos < len(s.buf); s.pos++ {
+		c := s.buf[s.pos]
+
+		if s.inString {
+			switch {
+			case s.escaped:
+				s.escaped = false
+			case c == '\\':
+				s.escaped = true
+			case c == '"':
+				s.inString = false
+				if s.depth == 1 {
+					s.lastKey = string(s.buf[s.strStart:s.pos])
+				}
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			s.inString = true
+			s.strStart = s.pos + 1
+		case '{', '[':
+			s.depth++
+			if c == '[' && s.depth == 2 && s.lastKey == "days" {
+				s.daysDepth = s.depth
+			}
+			if c == '{' && s.daysDepth > 0 && s.depth == s.daysDepth+1 {
+				s.dayStart = s.pos
+			}
+		case '}', ']':
+			if c == '}' && s.daysDepth > 0 && s.depth == s.daysDepth+1 && s.dayStart >= 0 {
+				days = append(days, string(s.buf[s.dayStart:s.pos+1]))
+				s.dayStart = -1
+			}
+			if c == ']' && s.depth == s.daysDepth {
+				s.daysDepth = -1
+			}
+			s.depth--
+		}
+	}
+
+	if !s.inString && s.dayStart < 0 {
+		s.buf = s.buf[:0]
+		s.pos = 0
+	}
+
+	return days
+}
+
+// completedDays returns the finished days in a (possibly partial) document.
+func completedDays(text string) []string {
+	return newDayScanner().scan(text)
+}
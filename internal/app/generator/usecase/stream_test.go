@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const streamedTrip = `{"title":"Bali \"escape\"","days":[{"day":1,"activities":[{"title":"Beach {sunset}"}]},{"day":2,"note":"]}"}],"summary":{"days":[{"day":9}]}}`
+
+func TestCompletedDays(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "whole document",
+			text: streamedTrip,
+			want: []string{`{"day":1,"activities":[{"title":"Beach {sunset}"}]}`, `{"day":2,"note":"]}"}`},
+		},
+		{
+			name: "second day still open",
+			text: streamedTrip[:strings.Index(streamedTrip, `"day":2`)+8],
+			want: []string{`{"day":1,"activities":[{"title":"Beach {sunset}"}]}`},
+		},
+		{
+			name: "no days yet",
+			text: `{"title":"Bali","days":[{"day":1`,
+			want: nil,
+		},
+		{
+			name: "nested days key is ignored",
+			text: `{"summary":{"days":[{"day":1}]}}`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := completedDays(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("completedDays() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayScannerChunks(t *testing.T) {
+	for _, size := range []int{1, 3, 7, len(streamedTrip)} {
+		scanner := newDayScanner()
+		var days []float64
+		for start := 0; start < len(streamedTrip); start += size {
+			end := min(start+size, len(streamedTrip))
+			for _, day := range scanner.Feed(streamedTrip[start:end]) {
+				days = append(days, day["day"].(float64))
+			}
+		}
+
+		if want := []float64{1, 2}; !reflect.DeepEqual(days, want) {
+			t.Errorf("chunk size %d: got days %v, want %v", size, days, want)
+		}
+	}
+}
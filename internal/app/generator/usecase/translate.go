@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"apac/internal/domain/dto"
+	"apac/internal/infra/prompt"
+	res "apac/internal/infra/response"
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// fixedFields are kept from the original trip whatever the translation says,
+// so a translated copy never moves a booking or changes a price.
+var fixedFields = map[string]bool{
+	"day":           true,
+	"date":          true,
+	"startDate":     true,
+	"endDate":       true,
+	"duration":      true,
+	"travelers":     true,
+	"time":          true,
+	"checkIn":       true,
+	"checkOut":      true,
+	"departureTime": true,
+	"arrivalTime":   true,
+	"cost":          true,
+	"budget":        true,
+	"totalCost":     true,
+	"address":       true,
+}
+
+// Translate stores a copy of the trip written in another language. Only
+// free text is taken from the model's answer; the structure, dates, times,
+// costs and addresses are copied from the original.
+func (uc *GeneratorUsecase) Translate(ctx context.Context, payload *dto.TranslateTripRequest, userId uuid.UUID, tripId uuid.UUID) (map[string]interface{}, *res.Err) {
+	if rerr := uc.usageUsecase.Check(userId); rerr != nil {
+		return nil, rerr
+	}
+
+	trip, err := uc.tripRepository.FindById(userId, tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip")
+	}
+
+	if trip == nil {
+		return nil, res.ErrNotFound("Trip not found")
+	}
+
+	if trip.Language != nil && *trip.Language == payload.Language {
+		return nil, res.ErrBadRequest("Trip is already in this language")
+	}
+
+	input, err := uc.prompt.Translate(trip.Content, payload.Language)
+	if err != nil {
+		return nil, promptError(err)
+	}
+
+	response, rerr := uc.generate(ctx, userId, input)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	original := trip.ParseDTOGet()
+	translated, ok := keepFixed(original, response).(map[string]interface{})
+	if !ok {
+		log.Println("Error: translated trip is not an object")
+		return nil, res.ErrBadGateway("AI service failed to translate the trip")
+	}
+
+	version := uc.prompt.Version(prompt.TemplateTranslate)
+	clone := uc.newTrip(userId, payload.Language, nil)
+	clone.PromptVersion = &version
+	clone.SourceID = &trip.ID
+	clone.BudgetAmount = trip.BudgetAmount
+	clone.BudgetCurrency = trip.BudgetCurrency
+
+	return uc.saveTrip(clone, translated)
+}
+
+// keepFixed walks original and translated side by side and returns original
+// with its text replaced by the translated text where both agree on shape.
+// Anything the translation dropped, added or reshaped is ignored.
+func keepFixed(original interface{}, translated interface{}) interface{} {
+	switch value := original.(type) {
+	case map[string]interface{}:
+		other, _ := translated.(map[string]interface{})
+		merged := make(map[string]interface{}, len(value))
+		for key, field := range value {
+			if fixedFields[key] || other == nil {
+				merged[key] = field
+				continue
+			}
+
+			merged[key] = keepFixed(field, other[key])
+		}
+
+		return merged
+	case []interface{}:
+		other, _ := translated.([]interface{})
+		merged := make([]interface{}, len(value))
+		for i, item := range value {
+			if len(other) != len(value) {
+				merged[i] = item
+				continue
+			}
+
+			merged[i] = keepFixed(item, other[i])
+		}
+
+		return merged
+	case string:
+		if text, ok := translated.(string); ok && text != "" {
+			return text
+		}
+
+		return value
+	default:
+		return value
+	}
+}
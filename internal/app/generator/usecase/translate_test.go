@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+)
+
+type object = map[string]interface{}
+type array = []interface{}
+
+func TestKeepFixed(t *testing.T) {
+	tests := []struct {
+		name       string
+		original   interface{}
+		translated interface{}
+		want       interface{}
+	}{
+		{
+			name:       "text is translated, fixed fields are kept",
+			original:   object{"title": "Beach day", "cost": "IDR 50,000", "days": array{object{"day": 1.0, "date": "2030-01-14", "notes": "Bring sunscreen"}}},
+			translated: object{"title": "Hari pantai", "cost": "IDR 99,000", "days": array{object{"day": 2.0, "date": "2030-02-01", "notes": "Bawa tabir surya"}}},
+			want:       object{"title": "Hari pantai", "cost": "IDR 50,000", "days": array{object{"day": 1.0, "date": "2030-01-14", "notes": "Bawa tabir surya"}}},
+		},
+		{
+			name:       "arrays of another length are not merged",
+			original:   object{"tips": array{"Go early", "Bring cash"}},
+			translated: object{"tips": array{"Datang pagi"}},
+			want:       object{"tips": array{"Go early", "Bring cash"}},
+		},
+		{
+			name:       "missing, empty and mistyped values fall back",
+			original:   object{"title": "Beach day", "summary": "Sun", "travelers": 2.0, "tags": array{"sea"}},
+			translated: object{"summary": "", "travelers": "dua", "tags": "laut", "extra": "ignored"},
+			want:       object{"title": "Beach day", "summary": "Sun", "travelers": 2.0, "tags": array{"sea"}},
+		},
+		{
+			name:       "translation that is not an object",
+			original:   object{"title": "Beach day"},
+			translated: "Hari pantai",
+			want:       object{"title": "Beach day"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keepFixed(tt.original, tt.translated); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keepFixed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"apac/internal/domain/dto"
+	"apac/internal/infra/cost"
+	res "apac/internal/infra/response"
+	"apac/internal/infra/schema"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var defaultVariants = []string{"budget", "balanced", "premium"}
+
+// Variants generates contrasting versions of the same request concurrently
+// and stores each one as a draft trip in a shared group. Drafts are deleted
+// after a week unless one of them is kept. Variants that fail are reported
+// next to the successful ones; the call only fails when none of them could
+// be generated.
+func (uc *GeneratorUsecase) Variants(ctx context.Context, payload *dto.GenerateVariantsRequest, userId uuid.UUID) (*dto.GenerateVariantsResponse, *res.Err) {
+	if rerr := uc.abuseUsecase.Screen(userId, "variants", payload.UserPlaces(), payload.Text); rerr != nil {
+		return nil, rerr
+	}
+
+	if rerr := uc.usageUsecase.Check(userId); rerr != nil {
+		return nil, rerr
+	}
+
+	input, _, rerr := uc.buildPrompt(&payload.GenerateTripRequest, userId)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	variants := payload.Variants
+	if len(variants) == 0 {
+		variants = defaultVariants
+	}
+
+	groupId, _ := uuid.NewV7()
+	results := make([]dto.TripVariantResponse, len(variants))
+	errs := make([]*res.Err, len(variants))
+
+	var wg sync.WaitGroup
+	for i, variant := range variants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			results[i].Variant = variant
+
+			variantInput, err := uc.prompt.Variant(input, variant)
+			if err != nil {
+				errs[i] = promptError(err)
+				results[i].Error = errs[i].Message
+				return
+			}
+
+			response, rerr := uc.generate(ctx, userId, variantInput)
+			if rerr != nil {
+				errs[i] = rerr
+				results[i].Error = rerr.Message
+				return
+			}
+
+			draft := uc.newTrip(userId, payload.Language, payload.Budget)
+			draft.VariantGroupID = &groupId
+			draft.Variant = &variant
+			draft.Draft = true
+
+			trip, rerr := uc.saveTrip(draft, response)
+			if rerr != nil {
+				errs[i] = rerr
+				results[i].Error = rerr.Message
+				return
+			}
+
+			results[i].ID = trip["id"].(uuid.UUID).String()
+			results[i].Trip = trip
+			results[i].Comparison = compare(trip)
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, rerr := range errs {
+		if rerr != nil {
+			failed++
+		}
+	}
+
+	if failed == len(errs) {
+		return nil, errs[0]
+	}
+
+	return &dto.GenerateVariantsResponse{
+		GroupID:  groupId.String(),
+		Variants: results,
+	}, nil
+}
+
+// compare summarizes the figures users weigh variants by.
+func compare(trip map[string]interface{}) *dto.TripComparison {
+	comparison := &dto.TripComparison{}
+
+	if total, ok := trip["totalCost"].(string); ok {
+		comparison.TotalCost = total
+		travelers, _ := trip["travelers"].(float64)
+		if amount := cost.ForParty(cost.Parse(total), int(travelers)); amount != nil {
+			comparison.TotalCostValue = &amount.Max
+		}
+	}
+
+	days, _ := trip["days"].([]interface{})
+	for _, d := range days {
+		day, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		activities, _ := day["activities"].([]interface{})
+		comparison.ActivityCount += len(activities)
+
+		transport, _ := day["transportation"].(map[string]interface{})
+		comparison.TravelMinutes += travelMinutes(transport)
+	}
+
+	return comparison
+}
+
+var clockLayouts = []string{"15:04", "3:04 PM", "3:04PM"}
+
+func travelMinutes(transport map[string]interface{}) int {
+	departure, _ := transport["departureTime"].(string)
+	arrival, _ := transport["arrivalTime"].(string)
+
+	start, ok := parseClock(departure)
+	if !ok {
+		return 0
+	}
+
+	end, ok := parseClock(arrival)
+	if !ok {
+		return 0
+	}
+
+	minutes := int(end.Sub(start).Minutes())
+	if minutes < 0 {
+		// Arrival after midnight.
+		minutes += 24 * 60
+	}
+
+	return minutes
+}
+
+func parseClock(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if t, err := schema.ParseDate(value); err == nil {
+		return time.Date(0, 1, 1, t.Hour(), t.Minute(), 0, 0, time.UTC), true
+	}
+
+	for _, layout := range clockLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
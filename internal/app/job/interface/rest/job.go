@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"apac/internal/app/job/usecase"
+	"apac/internal/domain/dto"
+	res "apac/internal/infra/response"
+	"apac/internal/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type JobHandler struct {
+	Validator  *validator.Validate
+	JobUsecase usecase.JobUsecaseItf
+}
+
+func NewJobHandler(routerGroup fiber.Router, jobUsecase usecase.JobUsecaseItf, m middleware.MiddlewareItf, validator *validator.Validate) {
+	jobHandler := JobHandler{
+		Validator:  validator,
+		JobUsecase: jobUsecase,
+	}
+
+	routerGroup.Post("/trips/generate", m.Authentication, jobHandler.Enqueue)
+	routerGroup.Get("/jobs/:id", m.Authentication, jobHandler.GetJob)
+}
+
+func (h JobHandler) Enqueue(ctx *fiber.Ctx) error {
+	payload := new(dto.GenerateTripRequest)
+	if err := ctx.BodyParser(&payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	userId := ctx.Locals("userID").(uuid.UUID)
+
+	job, err := h.JobUsecase.Enqueue(payload, userId)
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(res.Res{
+		StatusCode: fiber.StatusAccepted,
+		Message:    "Trip generation queued",
+		Payload:    job,
+	})
+}
+
+func (h JobHandler) GetJob(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	jobId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid job id"))
+	}
+
+	job, errs := h.JobUsecase.GetJob(userId, jobId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Job retrieved successfully", job)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"apac/internal/domain/entity"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobRepositoryItf interface {
+	Create(job *entity.GenerationJob) (*entity.GenerationJob, error)
+	FindById(userId uuid.UUID, jobId uuid.UUID) (*entity.GenerationJob, error)
+	ClaimNext(lease time.Duration) (*entity.GenerationJob, error)
+	Complete(jobId uuid.UUID, tripId uuid.UUID) error
+	Fail(jobId uuid.UUID, message string) error
+	Retry(jobId uuid.UUID, message string, after time.Duration) error
+	Renew(jobId uuid.UUID, lease time.Duration) error
+}
+
+type JobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) JobRepositoryItf {
+	return &JobRepository{db}
+}
+
+func (r *JobRepository) Create(job *entity.GenerationJob) (*entity.GenerationJob, error) {
+	if err := r.db.Create(job).Error; err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (r *JobRepository) FindById(userId uuid.UUID, jobId uuid.UUID) (*entity.GenerationJob, error) {
+	var job entity.GenerationJob
+	err := r.db.Where("user_id = ?", userId).Where("id = ?", jobId).First(&job).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// ClaimNext marks the oldest available job as running for lease and returns
+// it. Available jobs are queued jobs whose retry delay has passed and running
+// jobs whose lease ran out because their worker died. SKIP LOCKED lets
+// several workers, or several API instances, poll the same table.
+func (r *JobRepository) ClaimNext(lease time.Duration) (*entity.GenerationJob, error) {
+	var job entity.GenerationJob
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND (available_at IS NULL OR available_at <= ?)", entity.JobQueued, now).
+			// Jobs claimed before leases were recorded expire a lease after they started.
+			Or("status = ? AND (available_at < ? OR (available_at IS NULL AND started_at < ?))", entity.JobRunning, now, now.Add(-lease)).
+			Order("created_at ASC").
+			First(&job).Error
+		if err != nil {
+			return err
+		}
+
+		leasedUntil := now.Add(lease)
+		job.Status = entity.JobRunning
+		job.StartedAt = &now
+		job.AvailableAt = &leasedUntil
+		job.Attempts++
+
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"status":       job.Status,
+			"started_at":   job.StartedAt,
+			"available_at": job.AvailableAt,
+			"attempts":     job.Attempts,
+		}).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *JobRepository) Complete(jobId uuid.UUID, tripId uuid.UUID) error {
+	return r.db.Model(&entity.GenerationJob{}).Where("id = ?", jobId).Updates(map[string]interface{}{
+		"status":      entity.JobSucceeded,
+		"trip_id":     tripId,
+		"finished_at": time.Now(),
+	}).Error
+}
+
+func (r *JobRepository) Fail(jobId uuid.UUID, message string) error {
+	return r.db.Model(&entity.GenerationJob{}).Where("id = ?", jobId).Updates(map[string]interface{}{
+		"status":      entity.JobFailed,
+		"error":       message,
+		"finished_at": time.Now(),
+	}).Error
+}
+
+// Retry puts a job back in the queue to be claimed again after a delay.
+func (r *JobRepository) Retry(jobId uuid.UUID, message string, after time.Duration) error {
+	return r.db.Model(&entity.GenerationJob{}).Where("id = ?", jobId).Updates(map[string]interface{}{
+		"status":       entity.JobQueued,
+		"error":        message,
+		"available_at": time.Now().Add(after),
+	}).Error
+}
+
+// Renew extends the lease of a job that is still running.
+func (r *JobRepository) Renew(jobId uuid.UUID, lease time.Duration) error {
+	return r.db.Model(&entity.GenerationJob{}).
+		Where("id = ? AND status = ?", jobId, entity.JobRunning).
+		Update("available_at", time.Now().Add(lease)).Error
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	abuse "apac/internal/app/abuse/usecase"
+	generator "apac/internal/app/generator/usecase"
+	"apac/internal/app/job/repository"
+	usage "apac/internal/app/usage/usecase"
+	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	"apac/internal/domain/env"
+	res "apac/internal/infra/response"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxAttempts  = 3
+	pollInterval = 2 * time.Second
+	// leaseDuration is how long a claimed job stays with its worker without
+	// a renewal. Workers renew it while generating, so only the jobs of a
+	// worker that died are picked up by others.
+	leaseDuration = 5 * time.Minute
+	retryDelay    = 30 * time.Second
+)
+
+type JobUsecaseItf interface {
+	Enqueue(payload *dto.GenerateTripRequest, userId uuid.UUID) (*dto.GenerationJobResponse, *res.Err)
+	GetJob(userId uuid.UUID, jobId uuid.UUID) (*dto.GenerationJobResponse, *res.Err)
+	Start(ctx context.Context) error
+}
+
+type JobUsecase struct {
+	jobRepository    repository.JobRepositoryItf
+	generatorUsecase generator.GeneratorUsecaseItf
+	usageUsecase     usage.UsageUsecaseItf
+	abuseUsecase     abuse.AbuseUsecaseItf
+	workers          int
+	wake             chan struct{}
+}
+
+func NewJobUsecase(env *env.Env, jobRepository repository.JobRepositoryItf, generatorUsecase generator.GeneratorUsecaseItf, usageUsecase usage.UsageUsecaseItf, abuseUsecase abuse.AbuseUsecaseItf) JobUsecaseItf {
+	return &JobUsecase{
+		jobRepository:    jobRepository,
+		generatorUsecase: generatorUsecase,
+		usageUsecase:     usageUsecase,
+		abuseUsecase:     abuseUsecase,
+		workers:          env.GenerationWorkers,
+		wake:             make(chan struct{}, env.GenerationWorkers),
+	}
+}
+
+func (uc *JobUsecase) Enqueue(payload *dto.GenerateTripRequest, userId uuid.UUID) (*dto.GenerationJobResponse, *res.Err) {
+	if rerr := uc.abuseUsecase.Screen(userId, "generate_job", payload.UserPlaces(), payload.Text); rerr != nil {
+		return nil, rerr
+	}
+
+	if rerr := uc.usageUsecase.Check(userId); rerr != nil {
+		return nil, rerr
+	}
+
+	request, err := json.Marshal(payload)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to encode generation request")
+	}
+
+	job, err := uc.jobRepository.Create(&entity.GenerationJob{
+		UserID:  userId,
+		Status:  entity.JobQueued,
+		Request: string(request),
+	})
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to enqueue generation job")
+	}
+
+	select {
+	case uc.wake <- struct{}{}:
+	default:
+	}
+
+	resp := job.ParseDTOGet()
+	return &resp, nil
+}
+
+func (uc *JobUsecase) GetJob(userId uuid.UUID, jobId uuid.UUID) (*dto.GenerationJobResponse, *res.Err) {
+	job, err := uc.jobRepository.FindById(userId, jobId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find job")
+	}
+
+	if job == nil {
+		return nil, res.ErrNotFound("Job not found")
+	}
+
+	resp := job.ParseDTOGet()
+	return &resp, nil
+}
+
+// Start launches the worker pool. Jobs left running by a process that died
+// are claimed again once their lease runs out. Workers stop when ctx is
+// cancelled.
+func (uc *JobUsecase) Start(ctx context.Context) error {
+	for i := 0; i < uc.workers; i++ {
+		go uc.work(ctx)
+	}
+
+	return nil
+}
+
+func (uc *JobUsecase) work(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := uc.jobRepository.ClaimNext(leaseDuration)
+		if err != nil {
+			log.Println("Error: failed to claim generation job:", err)
+		}
+
+		if job != nil {
+			uc.run(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-uc.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (uc *JobUsecase) run(ctx context.Context, job *entity.GenerationJob) {
+	if job.Attempts > maxAttempts {
+		uc.fail(job, "Job exceeded retry limit")
+		return
+	}
+
+	payload := new(dto.GenerateTripRequest)
+	if err := json.Unmarshal([]byte(job.Request), payload); err != nil {
+		uc.fail(job, "Invalid generation request")
+		return
+	}
+
+	stop := uc.renew(job)
+	response, rerr := uc.generatorUsecase.Prompt(ctx, payload, job.UserID)
+	stop()
+
+	// A job cut short by shutdown keeps its lease and is picked up again
+	// once the lease runs out.
+	if ctx.Err() != nil {
+		return
+	}
+
+	if rerr != nil && retryable(rerr) && job.Attempts < maxAttempts {
+		if err := uc.jobRepository.Retry(job.ID, rerr.Message, retryDelay*time.Duration(job.Attempts)); err != nil {
+			log.Println("Error: failed to requeue generation job:", err)
+		}
+		return
+	}
+
+	if rerr != nil {
+		uc.fail(job, rerr.Message)
+		return
+	}
+
+	tripId, ok := response["id"].(uuid.UUID)
+	if !ok {
+		uc.fail(job, "Generated trip has no ID")
+		return
+	}
+
+	if err := uc.jobRepository.Complete(job.ID, tripId); err != nil {
+		log.Println("Error: failed to complete generation job:", err)
+	}
+}
+
+// renew keeps the job's lease alive until the returned func is called.
+func (uc *JobUsecase) renew(job *entity.GenerationJob) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(leaseDuration / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := uc.jobRepository.Renew(job.ID, leaseDuration); err != nil {
+					log.Println("Error: failed to renew generation job lease:", err)
+				}
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// retryable reports whether a failure came from the AI service being
+// unavailable or slow, which a later attempt may get past. Rejected or
+// invalid requests fail straight away.
+func retryable(err *res.Err) bool {
+	switch err.Code {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func (uc *JobUsecase) fail(job *entity.GenerationJob, message string) {
+	if err := uc.jobRepository.Fail(job.ID, message); err != nil {
+		log.Println("Error: failed to mark generation job as failed:", err)
+	}
+}
@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"apac/internal/app/packing/usecase"
+	"apac/internal/domain/dto"
+	res "apac/internal/infra/response"
+	"apac/internal/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PackingHandler struct {
+	Validator      *validator.Validate
+	PackingUsecase usecase.PackingUsecaseItf
+}
+
+func NewPackingHandler(routerGroup fiber.Router, packingUsecase usecase.PackingUsecaseItf, m middleware.MiddlewareItf, validator *validator.Validate) {
+	PackingHandler := PackingHandler{
+		Validator:      validator,
+		PackingUsecase: packingUsecase,
+	}
+
+	routerGroup = routerGroup.Group("/trips/:id/packing-list")
+	routerGroup.Post("/", m.Authentication, PackingHandler.Generate)
+	routerGroup.Get("/", m.Authentication, PackingHandler.GetList)
+	routerGroup.Post("/items", m.Authentication, PackingHandler.AddItem)
+	routerGroup.Patch("/items/:item", m.Authentication, PackingHandler.UpdateItem)
+	routerGroup.Delete("/items/:item", m.Authentication, PackingHandler.DeleteItem)
+}
+
+func (h PackingHandler) Generate(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	list, errs := h.PackingUsecase.Generate(userId, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Packing list generated successfully", list)
+}
+
+func (h PackingHandler) GetList(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	list, errs := h.PackingUsecase.GetList(userId, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Packing list retrieved successfully", list)
+}
+
+func (h PackingHandler) AddItem(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	payload := new(dto.AddPackingItemRequest)
+	if err := ctx.BodyParser(payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	item, errs := h.PackingUsecase.AddItem(userId, tripId, payload)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Packing item added successfully", item)
+}
+
+func (h PackingHandler) UpdateItem(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	itemId, err := uuid.Parse(ctx.Params("item"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid packing item id"))
+	}
+
+	payload := new(dto.UpdatePackingItemRequest)
+	if err := ctx.BodyParser(payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	item, errs := h.PackingUsecase.UpdateItem(userId, tripId, itemId, payload)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Packing item updated successfully", item)
+}
+
+func (h PackingHandler) DeleteItem(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	itemId, err := uuid.Parse(ctx.Params("item"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid packing item id"))
+	}
+
+	if errs := h.PackingUsecase.DeleteItem(userId, tripId, itemId); errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Packing item deleted successfully", nil)
+}
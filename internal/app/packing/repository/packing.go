@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"apac/internal/domain/entity"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PackingRepositoryItf interface {
+	FindByTrip(tripId uuid.UUID) ([]entity.PackingItem, error)
+	FindById(tripId uuid.UUID, itemId uuid.UUID) (*entity.PackingItem, error)
+	Replace(tripId uuid.UUID, items []entity.PackingItem) error
+	Create(item *entity.PackingItem) error
+	Update(item *entity.PackingItem) error
+	Delete(tripId uuid.UUID, itemId uuid.UUID) error
+}
+
+type PackingRepository struct {
+	db *gorm.DB
+}
+
+func NewPackingRepository(db *gorm.DB) PackingRepositoryItf {
+	return &PackingRepository{db}
+}
+
+func (r *PackingRepository) FindByTrip(tripId uuid.UUID) ([]entity.PackingItem, error) {
+	var items []entity.PackingItem
+	err := r.db.Where("trip_id = ?", tripId).Order("position ASC").Order("created_at ASC").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *PackingRepository) FindById(tripId uuid.UUID, itemId uuid.UUID) (*entity.PackingItem, error) {
+	var item entity.PackingItem
+	err := r.db.Where("trip_id = ?", tripId).Where("id = ?", itemId).First(&item).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// Replace swaps the generated items of a trip for items, leaving the user's
+// custom items in place.
+func (r *PackingRepository) Replace(tripId uuid.UUID, items []entity.PackingItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("trip_id = ?", tripId).Where("custom = ?", false).Delete(&entity.PackingItem{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			return nil
+		}
+
+		return tx.Create(&items).Error
+	})
+}
+
+func (r *PackingRepository) Create(item *entity.PackingItem) error {
+	return r.db.Create(item).Error
+}
+
+func (r *PackingRepository) Update(item *entity.PackingItem) error {
+	return r.db.Save(item).Error
+}
+
+func (r *PackingRepository) Delete(tripId uuid.UUID, itemId uuid.UUID) error {
+	return r.db.Where("trip_id = ?", tripId).Where("id = ?", itemId).Delete(&entity.PackingItem{}).Error
+}
@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"apac/internal/app/packing/repository"
+	trepo "apac/internal/app/trip/repository"
+	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	"apac/internal/infra/packing"
+	res "apac/internal/infra/response"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type PackingUsecaseItf interface {
+	Generate(userId uuid.UUID, tripId uuid.UUID) (*dto.PackingListResponse, *res.Err)
+	GetList(userId uuid.UUID, tripId uuid.UUID) (*dto.PackingListResponse, *res.Err)
+	AddItem(userId uuid.UUID, tripId uuid.UUID, payload *dto.AddPackingItemRequest) (*dto.PackingItemResponse, *res.Err)
+	UpdateItem(userId uuid.UUID, tripId uuid.UUID, itemId uuid.UUID, payload *dto.UpdatePackingItemRequest) (*dto.PackingItemResponse, *res.Err)
+	DeleteItem(userId uuid.UUID, tripId uuid.UUID, itemId uuid.UUID) *res.Err
+}
+
+type PackingUsecase struct {
+	packing           packing.PackingItf
+	packingRepository repository.PackingRepositoryItf
+	tripRepository    trepo.TripRepositoryItf
+}
+
+func NewPackingUsecase(packing packing.PackingItf, packingRepository repository.PackingRepositoryItf, tripRepository trepo.TripRepositoryItf) PackingUsecaseItf {
+	return &PackingUsecase{
+		packing:           packing,
+		packingRepository: packingRepository,
+		tripRepository:    tripRepository,
+	}
+}
+
+// Generate builds the checklist from the trip's current revision. Running it
+// again refreshes the generated items, keeps whatever the user already
+// ticked off and leaves added or edited items alone.
+func (uc *PackingUsecase) Generate(userId uuid.UUID, tripId uuid.UUID) (*dto.PackingListResponse, *res.Err) {
+	trip, rerr := uc.findTrip(userId, tripId)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	existing, err := uc.packingRepository.FindByTrip(tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find packing list")
+	}
+
+	checked := make(map[string]bool)
+	custom := make(map[string]bool)
+	for _, item := range existing {
+		if item.Custom {
+			custom[itemKey(item.Category, item.Name)] = true
+		} else if item.Checked {
+			checked[itemKey(item.Category, item.Name)] = true
+		}
+	}
+
+	items := make([]entity.PackingItem, 0)
+	for _, item := range uc.packing.Build(trip.ParseDTOGet()) {
+		key := itemKey(item.Category, item.Name)
+		if custom[key] {
+			continue
+		}
+
+		items = append(items, entity.PackingItem{
+			TripID:   tripId,
+			Category: item.Category,
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Position: len(items),
+			Checked:  checked[key],
+		})
+	}
+
+	if err := uc.packingRepository.Replace(tripId, items); err != nil {
+		return nil, res.ErrInternalServer("Failed to save packing list")
+	}
+
+	return uc.GetList(userId, tripId)
+}
+
+func (uc *PackingUsecase) GetList(userId uuid.UUID, tripId uuid.UUID) (*dto.PackingListResponse, *res.Err) {
+	if _, rerr := uc.findTrip(userId, tripId); rerr != nil {
+		return nil, rerr
+	}
+
+	items, err := uc.packingRepository.FindByTrip(tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find packing list")
+	}
+
+	if len(items) == 0 {
+		return nil, res.ErrNotFound("Packing list not found")
+	}
+
+	resp := &dto.PackingListResponse{
+		TripID:     tripId.String(),
+		Total:      len(items),
+		Categories: make([]dto.PackingCategoryResponse, 0),
+	}
+
+	grouped := make(map[string][]dto.PackingItemResponse)
+	for _, item := range items {
+		if item.Checked {
+			resp.Packed++
+		}
+		grouped[item.Category] = append(grouped[item.Category], item.ParseDTOGet())
+	}
+
+	for _, category := range packing.Categories {
+		if len(grouped[category]) == 0 {
+			continue
+		}
+
+		resp.Categories = append(resp.Categories, dto.PackingCategoryResponse{
+			Category: category,
+			Items:    grouped[category],
+		})
+	}
+
+	return resp, nil
+}
+
+func (uc *PackingUsecase) AddItem(userId uuid.UUID, tripId uuid.UUID, payload *dto.AddPackingItemRequest) (*dto.PackingItemResponse, *res.Err) {
+	if _, rerr := uc.findTrip(userId, tripId); rerr != nil {
+		return nil, rerr
+	}
+
+	items, err := uc.packingRepository.FindByTrip(tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find packing list")
+	}
+
+	position := 0
+	if len(items) > 0 {
+		position = slices.MaxFunc(items, func(a, b entity.PackingItem) int {
+			return a.Position - b.Position
+		}).Position + 1
+	}
+
+	item := &entity.PackingItem{
+		TripID:   tripId,
+		Category: payload.Category,
+		Name:     payload.Name,
+		Quantity: max(payload.Quantity, 1),
+		Position: position,
+		Custom:   true,
+	}
+
+	if err := uc.packingRepository.Create(item); err != nil {
+		return nil, res.ErrInternalServer("Failed to add packing item")
+	}
+
+	resp := item.ParseDTOGet()
+	return &resp, nil
+}
+
+// UpdateItem applies the fields present in payload. Changing anything but
+// the checkmark turns the item into a custom one, so regenerating the list
+// does not undo the edit.
+func (uc *PackingUsecase) UpdateItem(userId uuid.UUID, tripId uuid.UUID, itemId uuid.UUID, payload *dto.UpdatePackingItemRequest) (*dto.PackingItemResponse, *res.Err) {
+	item, rerr := uc.findItem(userId, tripId, itemId)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	if payload.Category != nil {
+		item.Category = *payload.Category
+		item.Custom = true
+	}
+
+	if payload.Name != nil {
+		item.Name = *payload.Name
+		item.Custom = true
+	}
+
+	if payload.Quantity != nil {
+		item.Quantity = *payload.Quantity
+		item.Custom = true
+	}
+
+	if payload.Checked != nil {
+		item.Checked = *payload.Checked
+	}
+
+	if err := uc.packingRepository.Update(item); err != nil {
+		return nil, res.ErrInternalServer("Failed to update packing item")
+	}
+
+	resp := item.ParseDTOGet()
+	return &resp, nil
+}
+
+func (uc *PackingUsecase) DeleteItem(userId uuid.UUID, tripId uuid.UUID, itemId uuid.UUID) *res.Err {
+	if _, rerr := uc.findItem(userId, tripId, itemId); rerr != nil {
+		return rerr
+	}
+
+	if err := uc.packingRepository.Delete(tripId, itemId); err != nil {
+		return res.ErrInternalServer("Failed to delete packing item")
+	}
+
+	return nil
+}
+
+func (uc *PackingUsecase) findTrip(userId uuid.UUID, tripId uuid.UUID) (*entity.Trip, *res.Err) {
+	trip, err := uc.tripRepository.FindById(userId, tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip")
+	}
+
+	if trip == nil {
+		return nil, res.ErrNotFound("Trip not found")
+	}
+
+	return trip, nil
+}
+
+func (uc *PackingUsecase) findItem(userId uuid.UUID, tripId uuid.UUID, itemId uuid.UUID) (*entity.PackingItem, *res.Err) {
+	if _, rerr := uc.findTrip(userId, tripId); rerr != nil {
+		return nil, rerr
+	}
+
+	item, err := uc.packingRepository.FindById(tripId, itemId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find packing item")
+	}
+
+	if item == nil {
+		return nil, res.ErrNotFound("Packing item not found")
+	}
+
+	return item, nil
+}
+
+func itemKey(category string, name string) string {
+	return category + "\x00" + name
+}
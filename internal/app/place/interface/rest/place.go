@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"apac/internal/app/place/usecase"
+	"apac/internal/domain/dto"
+	"apac/internal/infra/helper"
+	res "apac/internal/infra/response"
+	"apac/internal/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PlaceHandler struct {
+	Validator    *validator.Validate
+	PlaceUsecase usecase.PlaceUsecaseItf
+	helper       helper.HelperItf
+}
+
+func NewPlaceHandler(routerGroup fiber.Router, placeUsecase usecase.PlaceUsecaseItf, m middleware.MiddlewareItf, validator *validator.Validate, helper helper.HelperItf) {
+	PlaceHandler := PlaceHandler{
+		Validator:    validator,
+		PlaceUsecase: placeUsecase,
+		helper:       helper,
+	}
+
+	routerGroup.Post("/places/import", m.Authentication, m.Admin, PlaceHandler.Import)
+	routerGroup.Get("/trips/:id/places", m.Authentication, PlaceHandler.GetTripPlaces)
+}
+
+func (h PlaceHandler) Import(ctx *fiber.Ctx) error {
+	payload := new(dto.ImportPlacesRequest)
+	if err := h.helper.FormParser(ctx, payload); err != nil {
+		return res.BadRequest(ctx, err.Error())
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	result, err := h.PlaceUsecase.Import(payload)
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Places imported successfully", result)
+}
+
+func (h PlaceHandler) GetTripPlaces(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	places, errs := h.PlaceUsecase.GetTripPlaces(userId, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Trip places retrieved successfully", places)
+}
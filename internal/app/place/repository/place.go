@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"apac/internal/domain/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PlaceRepositoryItf interface {
+	Save(places []entity.Place) error
+	Search(name string, limit int) ([]entity.Place, error)
+	Count() (int64, error)
+}
+
+type PlaceRepository struct {
+	db *gorm.DB
+}
+
+func NewPlaceRepository(db *gorm.DB) PlaceRepositoryItf {
+	return &PlaceRepository{db}
+}
+
+// Save inserts places, overwriting places already imported under the same
+// OSM id.
+func (r *PlaceRepository) Save(places []entity.Place) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "osm_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "normalized_name", "category", "latitude", "longitude", "opening_hours", "address", "city", "updated_at"}),
+	}).CreateInBatches(&places, 500).Error
+}
+
+// Search returns the limit places whose normalized name is most similar to
+// name by trigram distance, closest first. The trigram index on
+// normalized_name answers the ordering without scanning the table.
+func (r *PlaceRepository) Search(name string, limit int) ([]entity.Place, error) {
+	var places []entity.Place
+	if name == "" {
+		return places, nil
+	}
+
+	err := r.db.Clauses(clause.OrderBy{
+		Expression: clause.Expr{SQL: "normalized_name <-> ?", Vars: []interface{}{name}},
+	}).Limit(limit).Find(&places).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return places, nil
+}
+
+func (r *PlaceRepository) Count() (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.Place{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
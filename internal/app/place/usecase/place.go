@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"apac/internal/app/place/repository"
+	trepo "apac/internal/app/trip/repository"
+	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	"apac/internal/infra/poi"
+	res "apac/internal/infra/response"
+	"io"
+	"log"
+	"math"
+	"os"
+
+	"github.com/google/uuid"
+)
+
+// candidatesPerName is how many of the most similar places are scored for
+// each activity title and location.
+const candidatesPerName = 10
+
+type PlaceUsecaseItf interface {
+	Import(payload *dto.ImportPlacesRequest) (*dto.ImportPlacesResponse, *res.Err)
+	SeedPlaces(path string) error
+	GetTripPlaces(userId uuid.UUID, tripId uuid.UUID) (*dto.TripPlacesResponse, *res.Err)
+}
+
+type PlaceUsecase struct {
+	placeRepository repository.PlaceRepositoryItf
+	tripRepository  trepo.TripRepositoryItf
+}
+
+func NewPlaceUsecase(placeRepository repository.PlaceRepositoryItf, tripRepository trepo.TripRepositoryItf) PlaceUsecaseItf {
+	return &PlaceUsecase{
+		placeRepository: placeRepository,
+		tripRepository:  tripRepository,
+	}
+}
+
+// Import adds the places of an uploaded extract. Places already imported
+// under the same OSM id are updated, so a newer extract can be loaded over
+// an older one.
+func (uc *PlaceUsecase) Import(payload *dto.ImportPlacesRequest) (*dto.ImportPlacesResponse, *res.Err) {
+	format := poi.Format(payload.File.Filename)
+	if format == "" {
+		return nil, res.ErrUnprocessableEntity("File must be a CSV or GeoJSON extract")
+	}
+
+	src, err := payload.File.Open()
+	if err != nil {
+		return nil, res.ErrInternalServer("Error opening file")
+	}
+
+	defer src.Close()
+
+	return uc.importPlaces(src, format)
+}
+
+// SeedPlaces imports the extract at path when no places have been imported
+// yet. An empty path disables seeding.
+func (uc *PlaceUsecase) SeedPlaces(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	count, err := uc.placeRepository.Count()
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	if _, rerr := uc.importPlaces(file, poi.Format(path)); rerr != nil {
+		return rerr
+	}
+
+	return nil
+}
+
+func (uc *PlaceUsecase) importPlaces(r io.Reader, format string) (*dto.ImportPlacesResponse, *res.Err) {
+	places, skipped, err := poi.Read(r, format)
+	if err != nil {
+		log.Println("Error reading places: ", err)
+		return nil, res.ErrUnprocessableEntity("Invalid points-of-interest file")
+	}
+
+	// An upsert cannot touch the same row twice, so later duplicates win.
+	seen := make(map[string]int, len(places))
+	rows := make([]entity.Place, 0, len(places))
+	for _, place := range places {
+		row := entity.Place{
+			OsmID:          place.OsmID,
+			Name:           place.Name,
+			NormalizedName: poi.Normalize(place.Name),
+			Category:       optional(place.Category),
+			Latitude:       place.Latitude,
+			Longitude:      place.Longitude,
+			OpeningHours:   optional(place.OpeningHours),
+			Address:        optional(place.Address),
+			City:           optional(place.City),
+		}
+
+		if i, ok := seen[place.OsmID]; ok {
+			rows[i] = row
+			skipped++
+			continue
+		}
+
+		seen[place.OsmID] = len(rows)
+		rows = append(rows, row)
+	}
+
+	if len(rows) > 0 {
+		if err := uc.placeRepository.Save(rows); err != nil {
+			return nil, res.ErrInternalServer("Failed to save places")
+		}
+	}
+
+	total, err := uc.placeRepository.Count()
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to count places")
+	}
+
+	return &dto.ImportPlacesResponse{
+		Imported: len(rows),
+		Skipped:  skipped,
+		Total:    total,
+	}, nil
+}
+
+// GetTripPlaces matches every activity of the trip against the imported
+// places. Matching happens on read, so trips pick up a newer extract as
+// soon as it is imported.
+func (uc *PlaceUsecase) GetTripPlaces(userId uuid.UUID, tripId uuid.UUID) (*dto.TripPlacesResponse, *res.Err) {
+	trip, err := uc.tripRepository.FindById(userId, tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip")
+	}
+
+	if trip == nil {
+		return nil, res.ErrNotFound("Trip not found")
+	}
+
+	count, err := uc.placeRepository.Count()
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to count places")
+	}
+
+	// Without a dataset every activity would look made up.
+	if count == 0 {
+		return nil, res.ErrServiceUnavailable("No places have been imported yet")
+	}
+
+	activities := tripActivities(trip.ParseDTOGet())
+
+	// Titles and locations repeat across a trip, so each is looked up once.
+	candidates := make(map[string][]entity.Place)
+
+	resp := &dto.TripPlacesResponse{
+		TripID:     trip.ID.String(),
+		Activities: make([]dto.ActivityPlaceResponse, 0, len(activities)),
+	}
+
+	for _, activity := range activities {
+		var best *entity.Place
+		for _, text := range []string{activity.Location, activity.Title} {
+			name := poi.Normalize(text)
+			if name == "" {
+				continue
+			}
+
+			places, ok := candidates[name]
+			if !ok {
+				places, err = uc.placeRepository.Search(name, candidatesPerName)
+				if err != nil {
+					return nil, res.ErrInternalServer("Failed to find places")
+				}
+				candidates[name] = places
+			}
+
+			for i := range places {
+				if score := poi.Score(text, activity.Address, toPOI(&places[i])); score > activity.Score {
+					activity.Score = score
+					best = &places[i]
+				}
+			}
+		}
+
+		activity.Score = math.Round(activity.Score*100) / 100
+		if best != nil && activity.Score >= poi.MinScore {
+			place := best.ParseDTOGet()
+			activity.Place = &place
+			resp.Matched++
+		} else {
+			activity.PossiblyHallucinated = true
+			resp.Unmatched++
+		}
+
+		resp.Activities = append(resp.Activities, activity)
+	}
+
+	return resp, nil
+}
+
+// tripActivities lists the trip's activities, addressed by day and activity
+// index like feedback is.
+func tripActivities(trip map[string]interface{}) []dto.ActivityPlaceResponse {
+	activities := make([]dto.ActivityPlaceResponse, 0)
+	days, _ := trip["days"].([]interface{})
+	for i, day := range days {
+		d, _ := day.(map[string]interface{})
+		items, _ := d["activities"].([]interface{})
+		for j, item := range items {
+			a, _ := item.(map[string]interface{})
+			title, _ := a["title"].(string)
+			location, _ := a["location"].(string)
+			address, _ := a["address"].(string)
+
+			activities = append(activities, dto.ActivityPlaceResponse{
+				Day:      i,
+				Activity: j,
+				Title:    title,
+				Location: location,
+				Address:  address,
+			})
+		}
+	}
+
+	return activities
+}
+
+// toPOI returns the place in the form the matcher scores.
+func toPOI(place *entity.Place) poi.Place {
+	candidate := poi.Place{OsmID: place.OsmID, Name: place.Name, Latitude: place.Latitude, Longitude: place.Longitude}
+	if place.City != nil {
+		candidate.City = *place.City
+	}
+
+	return candidate
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"apac/internal/app/preference/usecase"
+	"apac/internal/domain/dto"
+	res "apac/internal/infra/response"
+	"apac/internal/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PreferenceHandler struct {
+	Validator         *validator.Validate
+	PreferenceUsecase usecase.PreferenceUsecaseItf
+}
+
+func NewPreferenceHandler(routerGroup fiber.Router, preferenceUsecase usecase.PreferenceUsecaseItf, validator *validator.Validate, m middleware.MiddlewareItf) {
+	preferenceHandler := PreferenceHandler{
+		Validator:         validator,
+		PreferenceUsecase: preferenceUsecase,
+	}
+
+	routerGroup = routerGroup.Group("/preferences")
+	routerGroup.Get("/catalog", preferenceHandler.GetCatalog)
+	routerGroup.Post("/catalog/import", m.Authentication, m.Admin, preferenceHandler.ImportCatalog)
+}
+
+func (h PreferenceHandler) GetCatalog(ctx *fiber.Ctx) error {
+	catalog, err := h.PreferenceUsecase.GetCatalog(ctx.Query("lang", "en"))
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Preference catalog retrieved successfully", catalog)
+}
+
+func (h PreferenceHandler) ImportCatalog(ctx *fiber.Ctx) error {
+	payload := new(dto.ImportPreferenceCatalogRequest)
+	if err := ctx.BodyParser(&payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	if err := h.PreferenceUsecase.ImportCatalog(payload); err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Preference catalog imported", nil)
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"apac/internal/domain/entity"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PreferenceRepositoryItf interface {
+	FindCatalog() ([]entity.PreferenceCategory, error)
+	FindTagsBySlugs(slugs []string) ([]entity.PreferenceTag, error)
+	CountCategories() (int64, error)
+	ImportCatalog(categories []entity.PreferenceCategory) error
+	FindUntagged() ([]entity.Preference, error)
+	LinkUntagged(ids []uuid.UUID, links map[uuid.UUID]entity.PreferenceTag) (int64, error)
+}
+
+type PreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewPreferenceRepository(db *gorm.DB) PreferenceRepositoryItf {
+	return &PreferenceRepository{db}
+}
+
+func (r *PreferenceRepository) FindCatalog() ([]entity.PreferenceCategory, error) {
+	var categories []entity.PreferenceCategory
+	err := r.db.
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC, slug ASC")
+		}).
+		Order("position ASC, slug ASC").
+		Find(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *PreferenceRepository) FindTagsBySlugs(slugs []string) ([]entity.PreferenceTag, error) {
+	var tags []entity.PreferenceTag
+	if len(slugs) == 0 {
+		return tags, nil
+	}
+
+	err := r.db.Where("slug IN ?", slugs).Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *PreferenceRepository) CountCategories() (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.PreferenceCategory{}).Count(&count).Error
+	return count, err
+}
+
+// ImportCatalog upserts categories and their tags by slug. Existing rows keep
+// their IDs so user preferences pointing at them stay valid.
+func (r *PreferenceRepository) ImportCatalog(categories []entity.PreferenceCategory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, category := range categories {
+			var existing entity.PreferenceCategory
+			err := tx.Where("slug = ?", category.Slug).First(&existing).Error
+
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				existing = entity.PreferenceCategory{
+					Slug:     category.Slug,
+					Labels:   category.Labels,
+					Icon:     category.Icon,
+					Position: category.Position,
+				}
+				if err := tx.Create(&existing).Error; err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			default:
+				if err := tx.Model(&existing).Select("labels", "icon", "position").Updates(entity.PreferenceCategory{
+					Labels:   category.Labels,
+					Icon:     category.Icon,
+					Position: category.Position,
+				}).Error; err != nil {
+					return err
+				}
+			}
+
+			for _, tag := range category.Tags {
+				var existingTag entity.PreferenceTag
+				err := tx.Where("slug = ?", tag.Slug).First(&existingTag).Error
+
+				switch {
+				case errors.Is(err, gorm.ErrRecordNotFound):
+					existingTag = entity.PreferenceTag{
+						CategoryID: existing.ID,
+						Slug:       tag.Slug,
+						Labels:     tag.Labels,
+						Icon:       tag.Icon,
+						Position:   tag.Position,
+					}
+					if err := tx.Create(&existingTag).Error; err != nil {
+						return err
+					}
+				case err != nil:
+					return err
+				default:
+					if err := tx.Model(&existingTag).Select("category_id", "labels", "icon", "position").Updates(entity.PreferenceTag{
+						CategoryID: existing.ID,
+						Labels:     tag.Labels,
+						Icon:       tag.Icon,
+						Position:   tag.Position,
+					}).Error; err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// FindUntagged returns preferences that are not linked to a catalog tag.
+func (r *PreferenceRepository) FindUntagged() ([]entity.Preference, error) {
+	var preferences []entity.Preference
+	if err := r.db.Where("tag_id IS NULL").Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// LinkUntagged points the preferences in links at their tag, unless the user
+// already has that tag, and deletes the rest of ids. It returns how many
+// rows were deleted.
+func (r *PreferenceRepository) LinkUntagged(ids []uuid.UUID, links map[uuid.UUID]entity.PreferenceTag) (int64, error) {
+	var removed int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for id, tag := range links {
+			err := tx.Exec(`UPDATE preferences SET tag_id = ?, name = ? WHERE id = ? AND NOT EXISTS (
+				SELECT 1 FROM preferences other WHERE other.user_id = preferences.user_id AND other.tag_id = ?
+			)`, tag.ID, tag.Slug, id, tag.ID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		result := tx.Where("id IN ? AND tag_id IS NULL", ids).Delete(&entity.Preference{})
+		removed = result.RowsAffected
+		return result.Error
+	})
+
+	return removed, err
+}
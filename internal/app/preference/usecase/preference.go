@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"apac/internal/app/preference/repository"
+	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	res "apac/internal/infra/response"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type PreferenceUsecaseItf interface {
+	GetCatalog(lang string) ([]dto.PreferenceCategoryResponse, *res.Err)
+	ImportCatalog(payload *dto.ImportPreferenceCatalogRequest) *res.Err
+	SeedCatalog(path string) error
+	Resolve(userId uuid.UUID, inputs []dto.PreferenceInput) ([]entity.Preference, *res.Err)
+	LinkUntagged() error
+}
+
+type PreferenceUsecase struct {
+	preferenceRepository repository.PreferenceRepositoryItf
+}
+
+func NewPreferenceUsecase(preferenceRepository repository.PreferenceRepositoryItf) PreferenceUsecaseItf {
+	return &PreferenceUsecase{
+		preferenceRepository: preferenceRepository,
+	}
+}
+
+func (uc *PreferenceUsecase) GetCatalog(lang string) ([]dto.PreferenceCategoryResponse, *res.Err) {
+	categories, err := uc.preferenceRepository.FindCatalog()
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find preference catalog")
+	}
+
+	resps := make([]dto.PreferenceCategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		tags := make([]dto.PreferenceTagResponse, 0, len(category.Tags))
+		for _, tag := range category.Tags {
+			tags = append(tags, dto.PreferenceTagResponse{
+				Slug:  tag.Slug,
+				Label: tag.Label(lang),
+				Icon:  tag.Icon,
+			})
+		}
+
+		resps = append(resps, dto.PreferenceCategoryResponse{
+			Slug:  category.Slug,
+			Label: category.Label(lang),
+			Icon:  category.Icon,
+			Tags:  tags,
+		})
+	}
+
+	return resps, nil
+}
+
+func (uc *PreferenceUsecase) ImportCatalog(payload *dto.ImportPreferenceCatalogRequest) *res.Err {
+	categories := make([]entity.PreferenceCategory, 0, len(payload.Categories))
+	seen := make(map[string]bool)
+
+	for i, c := range payload.Categories {
+		labels, err := json.Marshal(c.Labels)
+		if err != nil {
+			return res.ErrBadRequest("Invalid labels for category " + c.Slug)
+		}
+
+		category := entity.PreferenceCategory{
+			Slug:     entity.NormalizeSlug(c.Slug),
+			Labels:   string(labels),
+			Icon:     c.Icon,
+			Position: i,
+		}
+
+		for j, t := range c.Tags {
+			slug := entity.NormalizeSlug(t.Slug)
+			if seen[slug] {
+				return res.ErrBadRequest("Duplicate preference tag: " + slug)
+			}
+			seen[slug] = true
+
+			labels, err := json.Marshal(t.Labels)
+			if err != nil {
+				return res.ErrBadRequest("Invalid labels for tag " + t.Slug)
+			}
+
+			category.Tags = append(category.Tags, entity.PreferenceTag{
+				Slug:     slug,
+				Labels:   string(labels),
+				Icon:     t.Icon,
+				Position: j,
+			})
+		}
+
+		categories = append(categories, category)
+	}
+
+	if err := uc.preferenceRepository.ImportCatalog(categories); err != nil {
+		return res.ErrInternalServer("Failed to import preference catalog")
+	}
+
+	return nil
+}
+
+// SeedCatalog imports the catalog file at path when the catalog is still
+// empty, so a fresh database has something to choose from.
+func (uc *PreferenceUsecase) SeedCatalog(path string) error {
+	count, err := uc.preferenceRepository.CountCategories()
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	payload := new(dto.ImportPreferenceCatalogRequest)
+	if err := json.Unmarshal(content, payload); err != nil {
+		return err
+	}
+
+	if err := uc.ImportCatalog(payload); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Resolve maps preference inputs onto catalog tags. Names are normalized
+// before lookup and anything outside the catalog is rejected, so typos
+// never reach prompts.
+func (uc *PreferenceUsecase) Resolve(userId uuid.UUID, inputs []dto.PreferenceInput) ([]entity.Preference, *res.Err) {
+	slugs := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		slugs = append(slugs, entity.NormalizeSlug(input.Name))
+	}
+
+	tags, err := uc.preferenceRepository.FindTagsBySlugs(slugs)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find preferences")
+	}
+
+	known := make(map[string]entity.PreferenceTag)
+	for _, tag := range tags {
+		known[tag.Slug] = tag
+	}
+
+	unknown := make([]string, 0)
+	preferences := make([]entity.Preference, 0, len(inputs))
+	positions := make(map[string]int)
+	for i, slug := range slugs {
+		tag, ok := known[slug]
+		if !ok {
+			unknown = append(unknown, slug)
+			continue
+		}
+
+		polarity := inputs[i].Polarity
+		if polarity == "" {
+			polarity = entity.PolarityLike
+		}
+
+		preference := entity.Preference{
+			UserID:   userId,
+			Name:     tag.Slug,
+			TagID:    &tag.ID,
+			Weight:   entity.ParseWeight(inputs[i].Weight),
+			Polarity: polarity,
+		}
+
+		// A repeated slug overrides the earlier entry instead of adding a duplicate row.
+		if pos, ok := positions[slug]; ok {
+			preferences[pos] = preference
+			continue
+		}
+
+		positions[slug] = len(preferences)
+		preferences = append(preferences, preference)
+	}
+
+	if len(unknown) > 0 {
+		return nil, res.ErrUnprocessableEntity("Unknown preferences: " + strings.Join(unknown, ", "))
+	}
+
+	return preferences, nil
+}
+
+// LinkUntagged cleans up preferences saved as free text before the catalog
+// existed. Rows whose normalized name is a catalog tag are linked to it; the
+// rest, and rows the user already has under that tag, are removed.
+func (uc *PreferenceUsecase) LinkUntagged() error {
+	preferences, err := uc.preferenceRepository.FindUntagged()
+	if err != nil {
+		return err
+	}
+
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	slugs := make([]string, 0, len(preferences))
+	for _, preference := range preferences {
+		slugs = append(slugs, entity.NormalizeSlug(preference.Name))
+	}
+
+	tags, err := uc.preferenceRepository.FindTagsBySlugs(slugs)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]entity.PreferenceTag, len(tags))
+	for _, tag := range tags {
+		known[tag.Slug] = tag
+	}
+
+	links := make(map[uuid.UUID]entity.PreferenceTag)
+	ids := make([]uuid.UUID, 0, len(preferences))
+	for i, preference := range preferences {
+		ids = append(ids, preference.ID)
+		if tag, ok := known[slugs[i]]; ok {
+			links[preference.ID] = tag
+		}
+	}
+
+	removed, err := uc.preferenceRepository.LinkUntagged(ids, links)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Linked %d free-text preferences to the catalog and removed %d", len(ids)-int(removed), removed)
+	return nil
+}
@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"apac/internal/app/taste/usecase"
+	res "apac/internal/infra/response"
+	"apac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TasteHandler struct {
+	TasteUsecase usecase.TasteUsecaseItf
+}
+
+func NewTasteHandler(routerGroup fiber.Router, tasteUsecase usecase.TasteUsecaseItf, m middleware.MiddlewareItf) {
+	TasteHandler := TasteHandler{
+		TasteUsecase: tasteUsecase,
+	}
+
+	routerGroup = routerGroup.Group("/user")
+	routerGroup.Get("/taste", m.Authentication, TasteHandler.GetProfile)
+}
+
+func (h TasteHandler) GetProfile(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+
+	profile, err := h.TasteUsecase.GetProfile(userId)
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Taste profile retrieved successfully", profile)
+}
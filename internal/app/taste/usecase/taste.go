@@ -0,0 +1,384 @@
+package usecase
+
+import (
+	currency "apac/internal/app/currency/usecase"
+	frepo "apac/internal/app/feedback/repository"
+	trepo "apac/internal/app/trip/repository"
+	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	"apac/internal/infra/cost"
+	res "apac/internal/infra/response"
+	"apac/internal/infra/schema"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxTags         = 8
+	maxDestinations = 10
+	maxPlaces       = 40
+	minTagCount     = 2
+
+	// Daily spend per traveler, in cost.BaseCurrency, above which a trip
+	// counts as moderate and luxury respectively.
+	moderateDailySpend = 60
+	luxuryDailySpend   = 200
+)
+
+// complaintReasons are the feedback reasons worth steering future trips
+// away from.
+var complaintReasons = map[string]bool{
+	"too_expensive":           true,
+	"too_packed":              true,
+	"too_relaxed":             true,
+	"too_much_travel":         true,
+	"not_my_style":            true,
+	"inaccurate_info":         true,
+	"place_closed_or_missing": true,
+	"unsafe":                  true,
+}
+
+// variantTiers maps kept variants onto the profile's budget tiers.
+var variantTiers = map[string]string{
+	"budget":   "budget",
+	"balanced": "moderate",
+	"premium":  "luxury",
+}
+
+type TasteUsecaseItf interface {
+	GetProfile(userId uuid.UUID) (*dto.TasteProfileResponse, *res.Err)
+}
+
+type TasteUsecase struct {
+	tripRepository     trepo.TripRepositoryItf
+	feedbackRepository frepo.FeedbackRepositoryItf
+	currencyUsecase    currency.CurrencyUsecaseItf
+}
+
+func NewTasteUsecase(tripRepository trepo.TripRepositoryItf, feedbackRepository frepo.FeedbackRepositoryItf, currencyUsecase currency.CurrencyUsecaseItf) TasteUsecaseItf {
+	return &TasteUsecase{
+		tripRepository:     tripRepository,
+		feedbackRepository: feedbackRepository,
+		currencyUsecase:    currencyUsecase,
+	}
+}
+
+// GetProfile derives the user's taste from the trips they kept and the
+// feedback they gave on them.
+func (uc *TasteUsecase) GetProfile(userId uuid.UUID) (*dto.TasteProfileResponse, *res.Err) {
+	trips, err := uc.tripRepository.FindAll(userId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trips")
+	}
+
+	feedback, err := uc.feedbackRepository.FindByUser(userId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find feedback")
+	}
+
+	rates, err := uc.currencyUsecase.Rates()
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find exchange rates")
+	}
+
+	sort.Slice(trips, func(i, j int) bool {
+		return newer(trips[i].CreatedAt, trips[j].CreatedAt)
+	})
+
+	today := time.Now().Truncate(24 * time.Hour)
+	tags := newCounter()
+	tiers := newCounter()
+	destinations := newList(maxDestinations)
+	contents := make(map[uuid.UUID]map[string]interface{}, len(trips))
+	current := make(map[uuid.UUID]int, len(trips))
+	ends := make(map[uuid.UUID]time.Time)
+
+	for _, trip := range trips {
+		content := trip.ParseDTOGet()
+		contents[trip.ID] = content
+		current[trip.ID] = trip.Revision
+
+		if destination, ok := content["destination"].(string); ok {
+			destinations.add(destination)
+		}
+
+		if tier := budgetTier(&trip, content, rates); tier != "" {
+			tiers.add(tier)
+		}
+
+		endDate, _ := content["endDate"].(string)
+		if end, err := schema.ParseDate(endDate); err == nil && end.Before(today) {
+			ends[trip.ID] = end
+		}
+
+		for _, activity := range activities(content) {
+			values, _ := activity["tags"].([]interface{})
+			for _, value := range values {
+				if tag, ok := value.(string); ok {
+					tags.add(strings.ToLower(strings.TrimSpace(tag)))
+				}
+			}
+		}
+	}
+
+	liked := newList(maxPlaces)
+	disliked := newList(maxPlaces)
+	complaints := newCounter()
+	taken := make(map[uuid.UUID]bool)
+	revisions := make(map[revisionKey]map[string]interface{})
+	for _, f := range feedback {
+		if f.Rating == entity.RatingDown {
+			reasons := make([]string, 0)
+			json.Unmarshal([]byte(f.Reasons), &reasons)
+			for _, reason := range reasons {
+				if complaintReasons[reason] {
+					complaints.add(reason)
+				}
+			}
+		}
+
+		// The trip of orphaned feedback is gone, along with its locations.
+		if f.TripID == nil {
+			continue
+		}
+
+		// A planned trip whose dates have passed may never have happened;
+		// feedback given after it ended shows the user actually went.
+		if end, ok := ends[*f.TripID]; ok && f.CreatedAt != nil && f.CreatedAt.After(end) {
+			taken[*f.TripID] = true
+		}
+
+		if f.Level != entity.FeedbackActivity {
+			continue
+		}
+
+		// Day and activity index into the revision that was rated, which a
+		// refine or regenerate may since have replaced.
+		content := contents[*f.TripID]
+		if revision, ok := current[*f.TripID]; ok && revision != f.Revision {
+			content, err = uc.revisionContent(revisions, *f.TripID, f.Revision)
+			if err != nil {
+				return nil, res.ErrInternalServer("Failed to find trip revision")
+			}
+		}
+
+		location := activityLocation(content, *f.Day, *f.Activity)
+		switch {
+		case location == "":
+		case f.Rating == entity.RatingUp:
+			liked.add(location)
+		case f.Rating == entity.RatingDown:
+			disliked.add(location)
+		}
+	}
+
+	visited := newList(maxPlaces)
+	for _, trip := range trips {
+		if !taken[trip.ID] {
+			continue
+		}
+
+		for _, activity := range activities(contents[trip.ID]) {
+			if location, ok := activity["location"].(string); ok {
+				visited.add(location)
+			}
+		}
+	}
+
+	return &dto.TasteProfileResponse{
+		TripCount:      len(trips),
+		FavoriteTags:   tags.top(maxTags, minTagCount),
+		BudgetTiers:    tiers.top(len(variantTiers), 1),
+		Destinations:   destinations.items,
+		VisitedPlaces:  visited.items,
+		LikedPlaces:    liked.items,
+		DislikedPlaces: disliked.items,
+		Complaints:     complaints.top(len(complaintReasons), 1),
+	}, nil
+}
+
+// budgetTier reads the tier off a kept variant, or else from the daily spend
+// per traveler. Like the itinerary's costs, the requested budget is for the
+// whole party, so the spend is the budget or, failing that, the itinerary's
+// total divided by the travelers.
+func budgetTier(trip *entity.Trip, content map[string]interface{}, rates cost.Rates) string {
+	if trip.Variant != nil {
+		if tier, ok := variantTiers[*trip.Variant]; ok {
+			return tier
+		}
+	}
+
+	days, _ := content["duration"].(float64)
+	travelers, _ := content["travelers"].(float64)
+
+	var total float64
+	if budget := trip.Budget(); budget != nil {
+		converted, ok := rates.Convert(budget.Amount, budget.Currency, cost.BaseCurrency)
+		if !ok {
+			return ""
+		}
+		total = converted
+	} else {
+		items, ok := trip.CostItems()
+		if !ok {
+			items = cost.Extract(content)
+		}
+		breakdown := cost.Summarize(items, "", &cost.Budget{Currency: cost.BaseCurrency}, rates, int(travelers))
+		total = breakdown.Total.Max
+	}
+
+	if total <= 0 || days < 1 || travelers < 1 {
+		return ""
+	}
+
+	switch daily := total / days / travelers; {
+	case daily >= luxuryDailySpend:
+		return "luxury"
+	case daily >= moderateDailySpend:
+		return "moderate"
+	default:
+		return "budget"
+	}
+}
+
+type revisionKey struct {
+	tripId uuid.UUID
+	number int
+}
+
+// revisionContent loads the itinerary of a stored trip revision, once per
+// revision. It is nil when the revision was not kept.
+func (uc *TasteUsecase) revisionContent(loaded map[revisionKey]map[string]interface{}, tripId uuid.UUID, number int) (map[string]interface{}, error) {
+	key := revisionKey{tripId: tripId, number: number}
+	if content, ok := loaded[key]; ok {
+		return content, nil
+	}
+
+	revision, err := uc.tripRepository.FindRevision(tripId, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var content map[string]interface{}
+	if revision != nil {
+		json.Unmarshal([]byte(revision.Content), &content)
+	}
+	loaded[key] = content
+
+	return content, nil
+}
+
+func activities(content map[string]interface{}) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+	days, _ := content["days"].([]interface{})
+	for _, day := range days {
+		d, _ := day.(map[string]interface{})
+		values, _ := d["activities"].([]interface{})
+		for _, value := range values {
+			if activity, ok := value.(map[string]interface{}); ok {
+				result = append(result, activity)
+			}
+		}
+	}
+
+	return result
+}
+
+func activityLocation(content map[string]interface{}, day int, activity int) string {
+	days, _ := content["days"].([]interface{})
+	if day < 0 || day >= len(days) {
+		return ""
+	}
+
+	d, _ := days[day].(map[string]interface{})
+	values, _ := d["activities"].([]interface{})
+	if activity < 0 || activity >= len(values) {
+		return ""
+	}
+
+	a, _ := values[activity].(map[string]interface{})
+	location, _ := a["location"].(string)
+
+	return location
+}
+
+func newer(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a != nil
+	}
+
+	return a.After(*b)
+}
+
+// counter tallies names, remembering the order they were first seen in to
+// break ties.
+type counter struct {
+	counts map[string]int
+	order  []string
+}
+
+func newCounter() *counter {
+	return &counter{counts: make(map[string]int)}
+}
+
+func (c *counter) add(name string) {
+	if name == "" {
+		return
+	}
+
+	if _, ok := c.counts[name]; !ok {
+		c.order = append(c.order, name)
+	}
+	c.counts[name]++
+}
+
+func (c *counter) top(limit int, min int) []dto.TasteCount {
+	names := make([]string, 0, len(c.order))
+	for _, name := range c.order {
+		if c.counts[name] >= min {
+			names = append(names, name)
+		}
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		return c.counts[names[i]] > c.counts[names[j]]
+	})
+
+	if len(names) > limit {
+		names = names[:limit]
+	}
+
+	result := make([]dto.TasteCount, 0, len(names))
+	for _, name := range names {
+		result = append(result, dto.TasteCount{Name: name, Count: c.counts[name]})
+	}
+
+	return result
+}
+
+// list keeps up to limit distinct names, compared case-insensitively, in the
+// order they were added.
+type list struct {
+	limit int
+	seen  map[string]bool
+	items []string
+}
+
+func newList(limit int) *list {
+	return &list{limit: limit, seen: make(map[string]bool), items: make([]string, 0)}
+}
+
+func (l *list) add(name string) {
+	name = strings.TrimSpace(name)
+	key := strings.ToLower(name)
+	if name == "" || l.seen[key] || len(l.items) >= l.limit {
+		return
+	}
+
+	l.seen[key] = true
+	l.items = append(l.items, name)
+}
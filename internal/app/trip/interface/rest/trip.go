@@ -22,6 +22,11 @@ func NewTripHandler(routerGroup fiber.Router, tripUsecase usecase.TripUsecaseItf
 	routerGroup.Get("/:id", m.Authentication, TripHandler.GetTripById)
 	routerGroup.Get("/", m.Authentication, TripHandler.GetAllTrips)
 	routerGroup.Delete("/:id", m.Authentication, TripHandler.Delete)
+	routerGroup.Get("/:id/revisions", m.Authentication, TripHandler.GetRevisions)
+	routerGroup.Get("/:id/revisions/:number", m.Authentication, TripHandler.GetRevision)
+	routerGroup.Get("/:id/messages", m.Authentication, TripHandler.GetMessages)
+	routerGroup.Post("/:id/keep", m.Authentication, TripHandler.KeepVariant)
+	routerGroup.Get("/:id/costs", m.Authentication, TripHandler.GetCosts)
 }
 
 func (h TripHandler) GetTripById(ctx *fiber.Ctx) error {
@@ -59,3 +64,83 @@ func (h TripHandler) Delete(ctx *fiber.Ctx) error {
 
 	return h.TripUsecase.Delete(userId, tripId)
 }
+
+func (h TripHandler) GetRevisions(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	revisions, errs := h.TripUsecase.GetRevisions(userId, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Trip revisions retrieved successfully", revisions)
+}
+
+func (h TripHandler) GetRevision(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	number, err := ctx.ParamsInt("number")
+	if err != nil || number < 1 {
+		return res.Error(ctx, res.ErrBadRequest("Invalid revision number"))
+	}
+
+	trip, errs := h.TripUsecase.GetRevision(userId, tripId, number)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Trip revision retrieved successfully", trip)
+}
+
+func (h TripHandler) GetMessages(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	messages, errs := h.TripUsecase.GetMessages(userId, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Trip conversation retrieved successfully", messages)
+}
+
+func (h TripHandler) KeepVariant(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	trip, errs := h.TripUsecase.KeepVariant(userId, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Trip variant kept successfully", trip)
+}
+
+func (h TripHandler) GetCosts(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+	tripId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.Error(ctx, res.ErrBadRequest("Invalid trip id"))
+	}
+
+	costs, errs := h.TripUsecase.GetCosts(userId, tripId)
+	if errs != nil {
+		return res.Error(ctx, errs)
+	}
+
+	return res.SuccessResponse(ctx, "Trip costs retrieved successfully", costs)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"apac/internal/domain/entity"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -13,8 +14,16 @@ type TripRepositoryItf interface {
 	FindById(userId uuid.UUID, tripId uuid.UUID) (*entity.Trip, error)
 	FindAll(userId uuid.UUID) ([]entity.Trip, error)
 	Delete(userId uuid.UUID, tripId uuid.UUID) error
+	SaveRevision(trip *entity.Trip, previous string, revision *entity.TripRevision, messages []entity.TripMessage) error
+	FindRevisions(tripId uuid.UUID) ([]entity.TripRevision, error)
+	FindRevision(tripId uuid.UUID, number int) (*entity.TripRevision, error)
+	FindMessages(tripId uuid.UUID) ([]entity.TripMessage, error)
+	KeepVariant(userId uuid.UUID, tripId uuid.UUID) (*entity.Trip, error)
+	DeleteStaleDrafts(before time.Time) (int64, error)
 }
 
+var ErrRevisionConflict = errors.New("trip was modified concurrently")
+
 type TripRepository struct {
 	db *gorm.DB
 }
@@ -24,7 +33,18 @@ func NewTripRepository(db *gorm.DB) TripRepositoryItf {
 }
 
 func (t *TripRepository) Create(trip *entity.Trip) (*entity.Trip, error) {
-	err := t.db.Create(trip).Error
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(trip).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&entity.TripRevision{
+			TripID:        trip.ID,
+			Number:        trip.Revision,
+			Content:       trip.Content,
+			PromptVersion: trip.PromptVersion,
+		}).Error
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +70,7 @@ func (t *TripRepository) FindById(userId uuid.UUID, tripId uuid.UUID) (*entity.T
 func (t *TripRepository) FindAll(userId uuid.UUID) ([]entity.Trip, error) {
 	var trips []entity.Trip
 
-	err := t.db.Where("user_id = ?", userId).Find(&trips).Error
+	err := t.db.Where("user_id = ?", userId).Where("draft = ?", false).Find(&trips).Error
 	if err != nil {
 		return nil, err
 	}
@@ -66,3 +86,132 @@ func (t *TripRepository) Delete(userId uuid.UUID, tripId uuid.UUID) error {
 
 	return nil
 }
+
+// SaveRevision stores trip's new content as revision.Number together with the
+// conversation turns that produced it. The update only applies when the trip
+// is still at the revision it was read at, otherwise ErrRevisionConflict is
+// returned. Trips created before revisions existed get their previous content
+// recorded first so it is not lost.
+func (t *TripRepository) SaveRevision(trip *entity.Trip, previous string, revision *entity.TripRevision, messages []entity.TripMessage) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.TripRevision{}).Where("trip_id = ?", trip.ID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count == 0 {
+			if err := tx.Create(&entity.TripRevision{
+				TripID:  trip.ID,
+				Number:  revision.Number - 1,
+				Content: previous,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(&entity.Trip{}).
+			Where("id = ?", trip.ID).
+			Where("revision = ?", revision.Number-1).
+			Updates(map[string]interface{}{"content": trip.Content, "costs": trip.Costs, "revision": revision.Number})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrRevisionConflict
+		}
+
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+
+		if len(messages) == 0 {
+			return nil
+		}
+
+		return tx.Create(&messages).Error
+	})
+}
+
+func (t *TripRepository) FindRevisions(tripId uuid.UUID) ([]entity.TripRevision, error) {
+	var revisions []entity.TripRevision
+
+	err := t.db.Omit("content").Where("trip_id = ?", tripId).Order("number ASC").Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (t *TripRepository) FindRevision(tripId uuid.UUID, number int) (*entity.TripRevision, error) {
+	var revision entity.TripRevision
+	err := t.db.Where("trip_id = ?", tripId).Where("number = ?", number).First(&revision).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
+func (t *TripRepository) FindMessages(tripId uuid.UUID) ([]entity.TripMessage, error) {
+	var messages []entity.TripMessage
+
+	err := t.db.Where("trip_id = ?", tripId).Order("created_at ASC, id ASC").Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// KeepVariant turns the draft variant into a regular trip and deletes the
+// other drafts generated alongside it. It returns nil when tripId is not a
+// draft variant owned by userId.
+func (t *TripRepository) KeepVariant(userId uuid.UUID, tripId uuid.UUID) (*entity.Trip, error) {
+	var trip entity.Trip
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ?", userId).
+			Where("id = ?", tripId).
+			Where("draft = ?", true).
+			Where("variant_group_id IS NOT NULL").
+			First(&trip).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&trip).Update("draft", false).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("variant_group_id = ?", trip.VariantGroupID).
+			Where("draft = ?", true).
+			Where("id <> ?", trip.ID).
+			Delete(&entity.Trip{}).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &trip, nil
+}
+
+// DeleteStaleDrafts deletes the draft variants created before the given time
+// and reports how many were removed.
+func (t *TripRepository) DeleteStaleDrafts(before time.Time) (int64, error) {
+	result := t.db.Where("draft = ?", true).
+		Where("created_at < ?", before).
+		Delete(&entity.Trip{})
+
+	return result.RowsAffected, result.Error
+}
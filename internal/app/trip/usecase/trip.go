@@ -1,27 +1,49 @@
 package usecase
 
 import (
+	currency "apac/internal/app/currency/usecase"
 	"apac/internal/app/trip/repository"
 	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	"apac/internal/infra/cost"
 	res "apac/internal/infra/response"
+	"context"
+	"encoding/json"
+	"log"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/uuid"
 )
 
+const (
+	// draftTTL is how long variants stay available to be kept. Groups the
+	// user never comes back to are deleted after that.
+	draftTTL           = 7 * 24 * time.Hour
+	draftSweepInterval = time.Hour
+)
+
 type TripUsecaseItf interface {
 	GetTripById(userId uuid.UUID, tripId uuid.UUID) (map[string]interface{}, *res.Err)
 	GetAllTrips(userId uuid.UUID) ([]dto.TripSummaryResponse, *res.Err)
 	Delete(userId uuid.UUID, tripId uuid.UUID) *res.Err
+	GetRevisions(userId uuid.UUID, tripId uuid.UUID) ([]dto.TripRevisionResponse, *res.Err)
+	GetRevision(userId uuid.UUID, tripId uuid.UUID, number int) (map[string]interface{}, *res.Err)
+	GetMessages(userId uuid.UUID, tripId uuid.UUID) ([]dto.TripMessageResponse, *res.Err)
+	KeepVariant(userId uuid.UUID, tripId uuid.UUID) (map[string]interface{}, *res.Err)
+	GetCosts(userId uuid.UUID, tripId uuid.UUID) (*dto.TripCostsResponse, *res.Err)
+	Start(ctx context.Context) error
 }
 
 type TripUsecase struct {
-	tripRepository repository.TripRepositoryItf
+	tripRepository  repository.TripRepositoryItf
+	currencyUsecase currency.CurrencyUsecaseItf
 }
 
-func NewTripUsecase(tripRepository repository.TripRepositoryItf) TripUsecaseItf {
+func NewTripUsecase(tripRepository repository.TripRepositoryItf, currencyUsecase currency.CurrencyUsecaseItf) TripUsecaseItf {
 	return &TripUsecase{
-		tripRepository: tripRepository,
+		tripRepository:  tripRepository,
+		currencyUsecase: currencyUsecase,
 	}
 }
 
@@ -36,6 +58,9 @@ func (uc *TripUsecase) GetTripById(userId uuid.UUID, tripId uuid.UUID) (map[stri
 	}
 
 	resp := trip.ParseDTOGet()
+	if trip.Language != nil {
+		resp["language"] = *trip.Language
+	}
 	return resp, nil
 }
 
@@ -54,6 +79,9 @@ func (uc *TripUsecase) GetAllTrips(userId uuid.UUID) ([]dto.TripSummaryResponse,
 		var resp dto.TripSummaryResponse
 		dtoResp := trip.ParseDTOGet()
 		dtoResp["id"] = trip.ID.String()
+		if trip.Language != nil {
+			dtoResp["language"] = *trip.Language
+		}
 		mapstructure.Decode(dtoResp, &resp)
 		resps = append(resps, resp)
 	}
@@ -68,3 +96,148 @@ func (uc *TripUsecase) Delete(userId uuid.UUID, tripId uuid.UUID) *res.Err {
 	}
 	return nil
 }
+
+func (uc *TripUsecase) GetRevisions(userId uuid.UUID, tripId uuid.UUID) ([]dto.TripRevisionResponse, *res.Err) {
+	if _, err := uc.findTrip(userId, tripId); err != nil {
+		return nil, err
+	}
+
+	revisions, err := uc.tripRepository.FindRevisions(tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip revisions")
+	}
+
+	resps := make([]dto.TripRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		resps = append(resps, revision.ParseDTOGet())
+	}
+
+	return resps, nil
+}
+
+func (uc *TripUsecase) GetRevision(userId uuid.UUID, tripId uuid.UUID, number int) (map[string]interface{}, *res.Err) {
+	if _, err := uc.findTrip(userId, tripId); err != nil {
+		return nil, err
+	}
+
+	revision, err := uc.tripRepository.FindRevision(tripId, number)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip revision")
+	}
+
+	if revision == nil {
+		return nil, res.ErrNotFound("Trip revision not found")
+	}
+
+	resp := make(map[string]interface{})
+	json.Unmarshal([]byte(revision.Content), &resp)
+
+	return resp, nil
+}
+
+func (uc *TripUsecase) GetMessages(userId uuid.UUID, tripId uuid.UUID) ([]dto.TripMessageResponse, *res.Err) {
+	if _, err := uc.findTrip(userId, tripId); err != nil {
+		return nil, err
+	}
+
+	messages, err := uc.tripRepository.FindMessages(tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip conversation")
+	}
+
+	resps := make([]dto.TripMessageResponse, 0, len(messages))
+	for _, message := range messages {
+		resps = append(resps, message.ParseDTOGet())
+	}
+
+	return resps, nil
+}
+
+func (uc *TripUsecase) KeepVariant(userId uuid.UUID, tripId uuid.UUID) (map[string]interface{}, *res.Err) {
+	trip, err := uc.tripRepository.KeepVariant(userId, tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to keep trip variant")
+	}
+
+	if trip == nil {
+		return nil, res.ErrNotFound("Trip variant not found")
+	}
+
+	resp := trip.ParseDTOGet()
+	resp["id"] = trip.ID
+
+	return resp, nil
+}
+
+func (uc *TripUsecase) findTrip(userId uuid.UUID, tripId uuid.UUID) (*entity.Trip, *res.Err) {
+	trip, err := uc.tripRepository.FindById(userId, tripId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find trip")
+	}
+
+	if trip == nil {
+		return nil, res.ErrNotFound("Trip not found")
+	}
+
+	return trip, nil
+}
+
+// GetCosts totals the trip's parsed costs per category, converted with the
+// current exchange rates, and compares them with the requested budget.
+func (uc *TripUsecase) GetCosts(userId uuid.UUID, tripId uuid.UUID) (*dto.TripCostsResponse, *res.Err) {
+	trip, rerr := uc.findTrip(userId, tripId)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	rates, err := uc.currencyUsecase.Rates()
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find exchange rates")
+	}
+
+	content := trip.ParseDTOGet()
+	items, ok := trip.CostItems()
+	if !ok {
+		items = cost.Extract(content)
+	}
+	stated, _ := content["totalCost"].(string)
+	travelers, _ := content["travelers"].(float64)
+
+	return &dto.TripCostsResponse{
+		CostBreakdown: cost.Summarize(items, stated, trip.Budget(), rates, int(travelers)),
+		Items:         items,
+	}, nil
+}
+
+// Start periodically deletes draft variants older than draftTTL until ctx is
+// cancelled.
+func (uc *TripUsecase) Start(ctx context.Context) error {
+	go func() {
+		ticker := time.NewTicker(draftSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			uc.deleteStaleDrafts()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (uc *TripUsecase) deleteStaleDrafts() {
+	deleted, err := uc.tripRepository.DeleteStaleDrafts(time.Now().Add(-draftTTL))
+	if err != nil {
+		log.Println("Error: failed to delete stale draft variants:", err)
+		return
+	}
+
+	if deleted > 0 {
+		log.Printf("Deleted %d stale draft variants\n", deleted)
+	}
+}
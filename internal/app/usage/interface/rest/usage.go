@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"apac/internal/app/usage/usecase"
+	res "apac/internal/infra/response"
+	"apac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type UsageHandler struct {
+	UsageUsecase usecase.UsageUsecaseItf
+}
+
+func NewUsageHandler(routerGroup fiber.Router, usageUsecase usecase.UsageUsecaseItf, m middleware.MiddlewareItf) {
+	UsageHandler := UsageHandler{
+		UsageUsecase: usageUsecase,
+	}
+
+	routerGroup = routerGroup.Group("/user")
+	routerGroup.Get("/usage", m.Authentication, UsageHandler.GetUsage)
+}
+
+func (h UsageHandler) GetUsage(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userID").(uuid.UUID)
+
+	usage, err := h.UsageUsecase.GetUsage(userId)
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Usage retrieved successfully", usage)
+}
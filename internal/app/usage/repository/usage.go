@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"apac/internal/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UsageRepositoryItf interface {
+	Create(usage *entity.AIUsage) error
+	Sum(userId uuid.UUID, since time.Time) (*Totals, error)
+}
+
+type Totals struct {
+	Requests       int
+	PromptTokens   int
+	ResponseTokens int
+}
+
+type UsageRepository struct {
+	db *gorm.DB
+}
+
+func NewUsageRepository(db *gorm.DB) UsageRepositoryItf {
+	return &UsageRepository{db}
+}
+
+func (r *UsageRepository) Create(usage *entity.AIUsage) error {
+	return r.db.Create(usage).Error
+}
+
+func (r *UsageRepository) Sum(userId uuid.UUID, since time.Time) (*Totals, error) {
+	var totals Totals
+	err := r.db.Model(&entity.AIUsage{}).
+		Select("COUNT(*) AS requests, COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens, COALESCE(SUM(response_tokens), 0) AS response_tokens").
+		Where("user_id = ?", userId).
+		Where("created_at >= ?", since).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &totals, nil
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"apac/internal/app/usage/repository"
+	urepo "apac/internal/app/user/repository"
+	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
+	"apac/internal/domain/env"
+	"apac/internal/infra/llm"
+	res "apac/internal/infra/response"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UsageUsecaseItf interface {
+	Record(userId uuid.UUID, usage llm.Usage)
+	Check(userId uuid.UUID) *res.Err
+	GetUsage(userId uuid.UUID) (*dto.UsageResponse, *res.Err)
+}
+
+type quota struct {
+	daily   int
+	monthly int
+}
+
+type UsageUsecase struct {
+	quotas          map[string]quota
+	usageRepository repository.UsageRepositoryItf
+	userRepository  urepo.UserRepositoryItf
+}
+
+func NewUsageUsecase(env *env.Env, usageRepository repository.UsageRepositoryItf, userRepository urepo.UserRepositoryItf) UsageUsecaseItf {
+	return &UsageUsecase{
+		quotas: map[string]quota{
+			entity.RoleUser:  {daily: env.QuotaUserDailyTokens, monthly: env.QuotaUserMonthlyTokens},
+			entity.RoleAdmin: {daily: env.QuotaAdminDailyTokens, monthly: env.QuotaAdminMonthlyTokens},
+		},
+		usageRepository: usageRepository,
+		userRepository:  userRepository,
+	}
+}
+
+// Record stores the tokens of one model call. Failing to record must not
+// fail the request that already paid for the call, so errors are only logged.
+func (uc *UsageUsecase) Record(userId uuid.UUID, usage llm.Usage) {
+	if usage.PromptTokens == 0 && usage.ResponseTokens == 0 {
+		return
+	}
+
+	err := uc.usageRepository.Create(&entity.AIUsage{
+		UserID:         userId,
+		Provider:       usage.Provider,
+		Model:          usage.Model,
+		PromptTokens:   usage.PromptTokens,
+		ResponseTokens: usage.ResponseTokens,
+	})
+	if err != nil {
+		log.Println("Error: failed to record AI usage:", err)
+	}
+}
+
+// Check returns 429 when the user has used up the daily or monthly token
+// quota of their role. The payload tells the client when it resets.
+func (uc *UsageUsecase) Check(userId uuid.UUID) *res.Err {
+	usage, rerr := uc.GetUsage(userId)
+	if rerr != nil {
+		return rerr
+	}
+
+	for _, window := range []struct {
+		name  string
+		usage dto.UsageWindowResponse
+	}{
+		{"daily", usage.Daily},
+		{"monthly", usage.Monthly},
+	} {
+		if window.usage.Limit == 0 || window.usage.Used < window.usage.Limit {
+			continue
+		}
+
+		err := res.ErrTooManyRequests("AI " + window.name + " quota exceeded, resets at " + window.usage.ResetAt.Format(time.RFC3339))
+		err.Payload = map[string]interface{}{
+			"window":   window.name,
+			"limit":    window.usage.Limit,
+			"used":     window.usage.Used,
+			"reset_at": window.usage.ResetAt,
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (uc *UsageUsecase) GetUsage(userId uuid.UUID) (*dto.UsageResponse, *res.Err) {
+	user, err := uc.userRepository.FindById(userId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find user")
+	}
+
+	if user == nil {
+		return nil, res.ErrNotFound("User not found")
+	}
+
+	q := uc.quotas[user.Role]
+	// Windows follow the server's local calendar, the same clock the
+	// created_at timestamps are written with.
+	now := time.Now()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	daily, rerr := uc.window(userId, day, day.AddDate(0, 0, 1), q.daily)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	monthly, rerr := uc.window(userId, month, month.AddDate(0, 1, 0), q.monthly)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	return &dto.UsageResponse{
+		Role:    user.Role,
+		Daily:   *daily,
+		Monthly: *monthly,
+	}, nil
+}
+
+func (uc *UsageUsecase) window(userId uuid.UUID, start time.Time, reset time.Time, limit int) (*dto.UsageWindowResponse, *res.Err) {
+	totals, err := uc.usageRepository.Sum(userId, start)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to sum AI usage")
+	}
+
+	window := &dto.UsageWindowResponse{
+		Limit:          limit,
+		Used:           totals.PromptTokens + totals.ResponseTokens,
+		PromptTokens:   totals.PromptTokens,
+		ResponseTokens: totals.ResponseTokens,
+		Requests:       totals.Requests,
+		ResetAt:        reset,
+	}
+
+	if limit > 0 {
+		remaining := max(limit-window.Used, 0)
+		window.Remaining = &remaining
+	}
+
+	return window, nil
+}
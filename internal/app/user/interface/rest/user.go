@@ -29,6 +29,7 @@ func NewUserHandler(routerGroup fiber.Router, userUsecase usecase.UserUsecaseItf
 	routerGroup.Get("/profile", m.Authentication, UserHandler.GetProfile)
 	routerGroup.Patch("/profile", m.Authentication, UserHandler.EditProfile)
 	routerGroup.Post("/preferences", m.Authentication, UserHandler.AddPreference)
+	routerGroup.Patch("/preferences", m.Authentication, UserHandler.UpdatePreferences)
 	routerGroup.Delete("/preferences/:name", m.Authentication, UserHandler.RemovePreference)
 }
 
@@ -82,6 +83,28 @@ func (h UserHandler) AddPreference(ctx *fiber.Ctx) error {
 	return res.SuccessResponse(ctx, "Preference updated", nil)
 }
 
+func (h UserHandler) UpdatePreferences(ctx *fiber.Ctx) error {
+	payload := new(dto.UpdatePreferenceRequest)
+	if err := ctx.BodyParser(&payload); err != nil {
+		return res.BadRequest(ctx)
+	}
+
+	if err := h.Validator.Struct(payload); err != nil {
+		return res.ValidationError(ctx, err)
+	}
+
+	userId := ctx.Locals("userID").(uuid.UUID)
+
+	preferences, err := h.UserUsecase.UpdatePreferences(userId, payload)
+	if err != nil {
+		return res.Error(ctx, err)
+	}
+
+	return res.SuccessResponse(ctx, "Preference updated", fiber.Map{
+		"preferences": preferences,
+	})
+}
+
 func (h UserHandler) RemovePreference(ctx *fiber.Ctx) error {
 	preferenceName := ctx.Params("name")
 	if preferenceName == "" {
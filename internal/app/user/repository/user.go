@@ -6,14 +6,15 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepositoryItf interface {
 	FindById(userId uuid.UUID) (*entity.User, error)
 	UpdateUser(userId uuid.UUID, user *entity.User) error
-	AddPreference(userId uuid.UUID, preference string) error
+	ReplacePreferences(userId uuid.UUID, preferences []entity.Preference) error
+	UpdatePreferences(userId uuid.UUID, add []entity.Preference, remove []string) error
 	RemovePreference(userId uuid.UUID, preference string) error
-	RemoveAllPreferences(userId uuid.UUID) error
 }
 
 type UserRepository struct {
@@ -36,7 +37,7 @@ func (r *UserRepository) FindById(userId uuid.UUID) (*entity.User, error) {
 		return nil, err
 	}
 
-	err = r.db.Preload("User").Where("user_id = ?", userId).Find(&user.Preference).Error
+	err = r.db.Preload("User").Preload("Tag").Where("user_id = ?", userId).Find(&user.Preference).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -59,21 +60,49 @@ func (r *UserRepository) FindById(userId uuid.UUID) (*entity.User, error) {
 	return &user, nil
 }
 
+// UpdateUser writes every column of user, so fields cleared to an empty
+// value are saved as well.
 func (r *UserRepository) UpdateUser(userId uuid.UUID, user *entity.User) error {
-	return r.db.Model(&entity.User{}).Where("id = ?", userId).Updates(user).Error
+	return r.db.Model(&entity.User{}).Where("id = ?", userId).Select("*").Omit("id", "created_at", clause.Associations).Updates(user).Error
 }
 
-func (r *UserRepository) AddPreference(userId uuid.UUID, preference string) error {
-	return r.db.Create(&entity.Preference{
-		UserID: userId,
-		Name:   preference,
-	}).Error
+func (r *UserRepository) ReplacePreferences(userId uuid.UUID, preferences []entity.Preference) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.Preference{}, "user_id = ?", userId).Error; err != nil {
+			return err
+		}
+
+		if len(preferences) == 0 {
+			return nil
+		}
+
+		return tx.Create(&preferences).Error
+	})
 }
 
-func (r *UserRepository) RemovePreference(userId uuid.UUID, preference string) error {
-	return r.db.Delete(&entity.Preference{}, "user_id = ? AND name = ?", userId, preference).Error
+// UpdatePreferences removes the given names and upserts the added preferences
+// in one transaction, so a failure leaves the previous set untouched.
+func (r *UserRepository) UpdatePreferences(userId uuid.UUID, add []entity.Preference, remove []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		names := append([]string{}, remove...)
+		for _, preference := range add {
+			names = append(names, preference.Name)
+		}
+
+		if len(names) > 0 {
+			if err := tx.Delete(&entity.Preference{}, "user_id = ? AND name IN ?", userId, names).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(add) == 0 {
+			return nil
+		}
+
+		return tx.Create(&add).Error
+	})
 }
 
-func (r *UserRepository) RemoveAllPreferences(userId uuid.UUID) error {
-	return r.db.Delete(&entity.Preference{}, "user_id = ?", userId).Error
+func (r *UserRepository) RemovePreference(userId uuid.UUID, preference string) error {
+	return r.db.Delete(&entity.Preference{}, "user_id = ? AND name = ?", userId, preference).Error
 }
@@ -1,12 +1,20 @@
 package usecase
 
 import (
+	preference "apac/internal/app/preference/usecase"
 	"apac/internal/app/user/repository"
 	"apac/internal/domain/dto"
+	"apac/internal/domain/entity"
 	"apac/internal/domain/env"
 	"apac/internal/infra/helper"
+	"apac/internal/infra/imaging"
 	res "apac/internal/infra/response"
 	"apac/internal/infra/supabase"
+	"bytes"
+	"encoding/json"
+	"log"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -17,22 +25,27 @@ type UserUsecaseItf interface {
 	GetProfile(userId uuid.UUID) (*dto.GetProfileResponse, *res.Err)
 	EditProfile(userId uuid.UUID, payload *dto.EditProfileRequest) *res.Err
 	AddPreference(userId uuid.UUID, payload *dto.AddPreferenceRequest) *res.Err
+	UpdatePreferences(userId uuid.UUID, payload *dto.UpdatePreferenceRequest) ([]dto.PreferenceResponse, *res.Err)
 	RemovePreference(userId uuid.UUID, preferenceName string) *res.Err
 }
 
 type UserUsecase struct {
-	userRepository repository.UserRepositoryItf
-	supabase       supabase.SupabaseItf
-	helper         helper.HelperItf
-	env            *env.Env
+	userRepository    repository.UserRepositoryItf
+	preferenceUsecase preference.PreferenceUsecaseItf
+	supabase          supabase.SupabaseItf
+	helper            helper.HelperItf
+	imaging           imaging.ImagingItf
+	env               *env.Env
 }
 
-func NewUserUsecase(env *env.Env, userRepository repository.UserRepositoryItf, supabase supabase.SupabaseItf, helper helper.HelperItf) UserUsecaseItf {
+func NewUserUsecase(env *env.Env, userRepository repository.UserRepositoryItf, preferenceUsecase preference.PreferenceUsecaseItf, supabase supabase.SupabaseItf, helper helper.HelperItf, imaging imaging.ImagingItf) UserUsecaseItf {
 	return &UserUsecase{
-		userRepository: userRepository,
-		supabase:       supabase,
-		helper:         helper,
-		env:            env,
+		userRepository:    userRepository,
+		preferenceUsecase: preferenceUsecase,
+		supabase:          supabase,
+		helper:            helper,
+		imaging:           imaging,
+		env:               env,
 	}
 }
 
@@ -60,8 +73,53 @@ func (uc *UserUsecase) EditProfile(userId uuid.UUID, payload *dto.EditProfileReq
 		return res.ErrNotFound("User not found")
 	}
 
-	if payload.Name != "" {
-		user.Name = payload.Name
+	if payload.Name != nil {
+		user.Name = *payload.Name
+	}
+
+	if payload.HomeCity != nil {
+		user.HomeCity = *payload.HomeCity
+	}
+
+	if payload.HomeCountry != nil {
+		user.HomeCountry = *payload.HomeCountry
+	}
+
+	if payload.Currency != nil {
+		user.Currency = strings.ToUpper(*payload.Currency)
+	}
+
+	if payload.Language != nil {
+		user.Language = *payload.Language
+	}
+
+	if payload.DietaryRestrictions != nil {
+		dietary := make([]string, 0)
+		for _, d := range strings.Split(*payload.DietaryRestrictions, ",") {
+			if d = strings.TrimSpace(d); d != "" {
+				dietary = append(dietary, d)
+			}
+		}
+
+		encoded, err := json.Marshal(dietary)
+		if err != nil {
+			return res.ErrInternalServer("Failed to encode dietary restrictions")
+		}
+
+		dietaryJSON := string(encoded)
+		user.DietaryRestrictions = &dietaryJSON
+	}
+
+	if payload.MobilityNeeds != nil {
+		user.MobilityNeeds = *payload.MobilityNeeds
+	}
+
+	if payload.BudgetTier != nil {
+		user.BudgetTier = *payload.BudgetTier
+	}
+
+	if payload.TravelStyle != nil {
+		user.TravelStyle = *payload.TravelStyle
 	}
 
 	if payload.CurrentPassword != "" && payload.NewPassword != "" {
@@ -78,6 +136,8 @@ func (uc *UserUsecase) EditProfile(userId uuid.UUID, payload *dto.EditProfileReq
 		user.Password = &hashedPassword
 	}
 
+	var oldPaths []string
+	newPaths := make(map[string]bool)
 	if payload.Photo != nil {
 		if err := uc.helper.ValidateImage(payload.Photo); err != nil {
 			return err
@@ -90,36 +150,101 @@ func (uc *UserUsecase) EditProfile(userId uuid.UUID, payload *dto.EditProfileReq
 
 		defer src.Close()
 
+		processed, err := uc.imaging.Process(src)
+		if err != nil {
+			return res.ErrUnprocessableEntity("Unable to process image")
+		}
+
 		bucket := uc.env.SupabaseBucket
+		oldPaths = uc.photoPaths(user)
 
-		if user.PhotoURL != uc.env.DefaultProfilePic {
-			currentPhotoURL := user.PhotoURL
-			index := strings.Index(currentPhotoURL, bucket)
-			currentPhotoPath := currentPhotoURL[index+len(bucket+"/"):]
+		variants := make(map[string]string)
+		for name, data := range processed.Variants {
+			path := "profiles/" + user.ID.String() + "/" + processed.Hash + "/" + name + imaging.Extension
 
-			if err := uc.supabase.DeleteFile(bucket, currentPhotoPath); err != nil {
-				return res.ErrInternalServer("Failed to delete file")
+			publicURL, err := uc.supabase.UploadFileFromIOReader(bucket, path, imaging.ContentType, bytes.NewReader(data))
+			if err != nil {
+				uc.deleteFiles(newPaths, oldPaths)
+				return res.ErrInternalServer("Failed to upload file")
 			}
-		}
 
-		path := "profiles/" + user.ID.String() + "/" + payload.Photo.Filename
-		contentType := payload.Photo.Header.Get("Content-Type")
+			variants[name] = publicURL
+			newPaths[path] = true
+		}
 
-		publicURL, err := uc.supabase.UploadFile(bucket, path, contentType, src)
+		encoded, err := json.Marshal(variants)
 		if err != nil {
-			return res.ErrInternalServer("Failed to upload file")
+			uc.deleteFiles(newPaths, oldPaths)
+			return res.ErrInternalServer("Failed to encode photo variants")
 		}
 
-		user.PhotoURL = publicURL
+		photoVariants := string(encoded)
+		user.PhotoURL = variants[imaging.Original]
+		user.PhotoVariants = &photoVariants
 	}
 
 	if err := uc.userRepository.UpdateUser(userId, user); err != nil {
+		uc.deleteFiles(newPaths, oldPaths)
 		return res.ErrInternalServer("Failed to update user")
 	}
 
+	// The old photo is only removed once the user points at the new one.
+	oldSet := make(map[string]bool, len(oldPaths))
+	for _, path := range oldPaths {
+		oldSet[path] = true
+	}
+	uc.deleteFiles(oldSet, slices.Collect(maps.Keys(newPaths)))
+
 	return nil
 }
 
+// deleteFiles removes paths from storage, except those listed in keep. A
+// file that cannot be deleted is logged and left behind rather than failing
+// a request whose outcome is already decided.
+func (uc *UserUsecase) deleteFiles(paths map[string]bool, keep []string) {
+	for path := range paths {
+		if slices.Contains(keep, path) {
+			continue
+		}
+
+		if err := uc.supabase.DeleteFile(uc.env.SupabaseBucket, path); err != nil {
+			log.Println("Error deleting file "+path+": ", err)
+		}
+	}
+}
+
+func (uc *UserUsecase) photoPaths(user *entity.User) []string {
+	if user.PhotoURL == uc.env.DefaultProfilePic {
+		return nil
+	}
+
+	urls := []string{user.PhotoURL}
+	if user.PhotoVariants != nil {
+		variants := make(map[string]string)
+		if err := json.Unmarshal([]byte(*user.PhotoVariants), &variants); err != nil {
+			log.Println("Error decoding photo variants of user "+user.ID.String()+": ", err)
+		}
+		for name, url := range variants {
+			if name != imaging.Original {
+				urls = append(urls, url)
+			}
+		}
+	}
+
+	bucket := uc.env.SupabaseBucket
+	paths := make([]string, 0, len(urls))
+	for _, url := range urls {
+		index := strings.Index(url, bucket)
+		if index < 0 {
+			continue
+		}
+
+		paths = append(paths, url[index+len(bucket+"/"):])
+	}
+
+	return paths
+}
+
 func (uc *UserUsecase) AddPreference(userId uuid.UUID, payload *dto.AddPreferenceRequest) *res.Err {
 	user, err := uc.userRepository.FindById(userId)
 	if err != nil {
@@ -131,17 +256,61 @@ func (uc *UserUsecase) AddPreference(userId uuid.UUID, payload *dto.AddPreferenc
 	}
 
 	if payload.Preferences != nil {
-		uc.userRepository.RemoveAllPreferences(userId)
-		for _, pref := range payload.Preferences {
-			if err := uc.userRepository.AddPreference(userId, pref); err != nil {
-				return res.ErrInternalServer("Failed to add preference")
-			}
+		preferences, rerr := uc.preferenceUsecase.Resolve(userId, payload.Preferences)
+		if rerr != nil {
+			return rerr
+		}
+
+		if err := uc.userRepository.ReplacePreferences(userId, preferences); err != nil {
+			return res.ErrInternalServer("Failed to update preferences")
 		}
 	}
 
 	return nil
 }
 
+func (uc *UserUsecase) UpdatePreferences(userId uuid.UUID, payload *dto.UpdatePreferenceRequest) ([]dto.PreferenceResponse, *res.Err) {
+	user, err := uc.userRepository.FindById(userId)
+	if err != nil {
+		return nil, res.ErrInternalServer("Failed to find user")
+	}
+
+	if user == nil {
+		return nil, res.ErrNotFound("User not found")
+	}
+
+	add, rerr := uc.preferenceUsecase.Resolve(userId, payload.Add)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	adding := make(map[string]bool)
+	for _, preference := range add {
+		adding[preference.Name] = true
+	}
+
+	remove := make([]string, 0, len(payload.Remove))
+	for _, name := range payload.Remove {
+		slug := entity.NormalizeSlug(name)
+		if adding[slug] {
+			return nil, res.ErrBadRequest("Preference cannot be both added and removed: " + slug)
+		}
+
+		remove = append(remove, slug)
+	}
+
+	if err := uc.userRepository.UpdatePreferences(userId, add, remove); err != nil {
+		return nil, res.ErrInternalServer("Failed to update preferences")
+	}
+
+	user, err = uc.userRepository.FindById(userId)
+	if err != nil || user == nil {
+		return nil, res.ErrInternalServer("Failed to find user")
+	}
+
+	return user.ParseDTOGet().Preferences, nil
+}
+
 func (uc *UserUsecase) RemovePreference(userId uuid.UUID, preferenceName string) *res.Err {
 	user, err := uc.userRepository.FindById(userId)
 	if err != nil {
@@ -152,7 +321,7 @@ func (uc *UserUsecase) RemovePreference(userId uuid.UUID, preferenceName string)
 		return res.ErrNotFound("User not found")
 	}
 
-	if err := uc.userRepository.RemovePreference(userId, preferenceName); err != nil {
+	if err := uc.userRepository.RemovePreference(userId, entity.NormalizeSlug(preferenceName)); err != nil {
 		return res.ErrInternalServer("Failed to remove preference")
 	}
 
@@ -4,14 +4,20 @@ import (
 	"apac/internal/domain/env"
 	"apac/internal/infra/email"
 	"apac/internal/infra/fiber"
-	"apac/internal/infra/gemini"
+	"apac/internal/infra/guard"
 	"apac/internal/infra/helper"
+	"apac/internal/infra/imaging"
 	"apac/internal/infra/jwt"
+	"apac/internal/infra/llm"
 	"apac/internal/infra/oauth"
+	"apac/internal/infra/packing"
 	"apac/internal/infra/postgresql"
+	"apac/internal/infra/prompt"
 	"apac/internal/infra/redis"
+	"apac/internal/infra/schema"
 	"apac/internal/infra/supabase"
 	"apac/internal/middleware"
+	"context"
 	"fmt"
 
 	AuthHandler "apac/internal/app/auth/interface/rest"
@@ -22,12 +28,47 @@ import (
 	UserRepo "apac/internal/app/user/repository"
 	UserUsecase "apac/internal/app/user/usecase"
 
+	CurrencyHandler "apac/internal/app/currency/interface/rest"
+	CurrencyRepo "apac/internal/app/currency/repository"
+	CurrencyUsecase "apac/internal/app/currency/usecase"
+
 	TripHandler "apac/internal/app/trip/interface/rest"
 	TripRepo "apac/internal/app/trip/repository"
 	TripUsecase "apac/internal/app/trip/usecase"
 
-	GeminiHandler "apac/internal/app/gemini/interface/rest"
-	GeminiUsecase "apac/internal/app/gemini/usecase"
+	PreferenceHandler "apac/internal/app/preference/interface/rest"
+	PreferenceRepo "apac/internal/app/preference/repository"
+	PreferenceUsecase "apac/internal/app/preference/usecase"
+
+	GeneratorHandler "apac/internal/app/generator/interface/rest"
+	GeneratorUsecase "apac/internal/app/generator/usecase"
+
+	AbuseHandler "apac/internal/app/abuse/interface/rest"
+	AbuseRepo "apac/internal/app/abuse/repository"
+	AbuseUsecase "apac/internal/app/abuse/usecase"
+
+	UsageHandler "apac/internal/app/usage/interface/rest"
+	UsageRepo "apac/internal/app/usage/repository"
+	UsageUsecase "apac/internal/app/usage/usecase"
+
+	FeedbackHandler "apac/internal/app/feedback/interface/rest"
+	FeedbackRepo "apac/internal/app/feedback/repository"
+	FeedbackUsecase "apac/internal/app/feedback/usecase"
+
+	PlaceHandler "apac/internal/app/place/interface/rest"
+	PlaceRepo "apac/internal/app/place/repository"
+	PlaceUsecase "apac/internal/app/place/usecase"
+
+	TasteHandler "apac/internal/app/taste/interface/rest"
+	TasteUsecase "apac/internal/app/taste/usecase"
+
+	PackingHandler "apac/internal/app/packing/interface/rest"
+	PackingRepo "apac/internal/app/packing/repository"
+	PackingUsecase "apac/internal/app/packing/usecase"
+
+	JobHandler "apac/internal/app/job/interface/rest"
+	JobRepo "apac/internal/app/job/repository"
+	JobUsecase "apac/internal/app/job/usecase"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2/middleware/monitor"
@@ -62,8 +103,19 @@ func Start() error {
 	r := redis.NewRedis(config)
 	s := supabase.NewSupabase(config)
 	h := helper.NewHelper(config)
+	img := imaging.NewImaging()
+	pb, err := prompt.NewPrompt(config)
+	if err != nil {
+		return err
+	}
+	gd := guard.NewGuard()
+	pk := packing.NewPacking()
 	m := middleware.NewMiddleware(j)
-	g, err := gemini.NewGemini(config)
+	g, err := llm.New(config)
+	if err != nil {
+		return err
+	}
+	sc, err := schema.NewSchema(llm.SchemaPath)
 	if err != nil {
 		return err
 	}
@@ -76,19 +128,74 @@ func Start() error {
 
 	userRepository := UserRepo.NewUserRepository(db)
 
-	authUsecase := AuthUsecase.NewAuthUsecase(config, db, r, authRepository, userRepository, j, e, o)
+	preferenceRepository := PreferenceRepo.NewPreferenceRepository(db)
+
+	preferenceUsecase := PreferenceUsecase.NewPreferenceUsecase(preferenceRepository)
+	if err := preferenceUsecase.SeedCatalog("./resource/preferences.json"); err != nil {
+		return err
+	}
+	if err := preferenceUsecase.LinkUntagged(); err != nil {
+		return err
+	}
+	PreferenceHandler.NewPreferenceHandler(v1, preferenceUsecase, v, m)
+
+	authUsecase := AuthUsecase.NewAuthUsecase(config, db, r, authRepository, userRepository, preferenceUsecase, j, e, o)
 	AuthHandler.NewAuthHandler(v1, authUsecase, config, v)
 
-	userUsecase := UserUsecase.NewUserUsecase(config, userRepository, s, h)
+	userUsecase := UserUsecase.NewUserUsecase(config, userRepository, preferenceUsecase, s, h, img)
 	UserHandler.NewUserHandler(v1, userUsecase, v, m, h)
 
+	currencyRepository := CurrencyRepo.NewCurrencyRepository(db)
+	currencyUsecase := CurrencyUsecase.NewCurrencyUsecase(currencyRepository)
+	if err := currencyUsecase.SeedRates("./resource/exchange_rates.json"); err != nil {
+		return err
+	}
+	CurrencyHandler.NewCurrencyHandler(v1, currencyUsecase, m, v)
+
 	tripRepository := TripRepo.NewTripRepository(db)
 
-	tripUsecase := TripUsecase.NewTripUsecase(tripRepository)
+	tripUsecase := TripUsecase.NewTripUsecase(tripRepository, currencyUsecase)
+	if err := tripUsecase.Start(context.Background()); err != nil {
+		return err
+	}
 	TripHandler.NewTripHandler(v1, tripUsecase, m)
 
-	geminiUsecase := GeminiUsecase.NewGeminiUsecase(config, g, userRepository, tripRepository)
-	GeminiHandler.NewGeminiHandler(v1, geminiUsecase, m, v)
+	packingRepository := PackingRepo.NewPackingRepository(db)
+	packingUsecase := PackingUsecase.NewPackingUsecase(pk, packingRepository, tripRepository)
+	PackingHandler.NewPackingHandler(v1, packingUsecase, m, v)
+
+	feedbackRepository := FeedbackRepo.NewFeedbackRepository(db)
+	feedbackUsecase := FeedbackUsecase.NewFeedbackUsecase(feedbackRepository, tripRepository)
+	FeedbackHandler.NewFeedbackHandler(v1, feedbackUsecase, m, v)
+
+	placeRepository := PlaceRepo.NewPlaceRepository(db)
+	placeUsecase := PlaceUsecase.NewPlaceUsecase(placeRepository, tripRepository)
+	if err := placeUsecase.SeedPlaces(config.POIDatasetPath); err != nil {
+		return err
+	}
+	PlaceHandler.NewPlaceHandler(v1, placeUsecase, m, v, h)
+
+	tasteUsecase := TasteUsecase.NewTasteUsecase(tripRepository, feedbackRepository, currencyUsecase)
+	TasteHandler.NewTasteHandler(v1, tasteUsecase, m)
+
+	abuseRepository := AbuseRepo.NewAbuseRepository(db)
+	abuseUsecase := AbuseUsecase.NewAbuseUsecase(gd, abuseRepository)
+	AbuseHandler.NewAbuseHandler(v1, abuseUsecase, m, v)
+
+	usageRepository := UsageRepo.NewUsageRepository(db)
+	usageUsecase := UsageUsecase.NewUsageUsecase(config, usageRepository, userRepository)
+	UsageHandler.NewUsageHandler(v1, usageUsecase, m)
+
+	generatorUsecase := GeneratorUsecase.NewGeneratorUsecase(config, g, sc, pb, userRepository, tripRepository, usageUsecase, r, abuseUsecase, tasteUsecase)
+	GeneratorHandler.NewGeneratorHandler(v1, generatorUsecase, m, v)
+
+	jobRepository := JobRepo.NewJobRepository(db)
+
+	jobUsecase := JobUsecase.NewJobUsecase(config, jobRepository, generatorUsecase, usageUsecase, abuseUsecase)
+	if err := jobUsecase.Start(context.Background()); err != nil {
+		return err
+	}
+	JobHandler.NewJobHandler(v1, jobUsecase, m, v)
 
 	return app.Listen(fmt.Sprintf("%s:%d", config.AppHost, config.AppPort))
 }
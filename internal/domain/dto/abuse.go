@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type PromptRejectionResponse struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	Source    string     `json:"source"`
+	Reason    string     `json:"reason"`
+	Detail    string     `json:"detail"`
+	Input     string     `json:"input"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type GetPromptRejectionsRequest struct {
+	Reason string `query:"reason" validate:"omitempty,oneof=too_long injection off_topic"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=200"`
+	Offset int    `query:"offset" validate:"omitempty,min=0"`
+}
@@ -12,8 +12,8 @@ type VerifyOTPRequest struct {
 }
 
 type ChoosePreferenceRequest struct {
-	Email       string   `json:"email" validate:"required,email"`
-	Preferences []string `json:"preferences" validate:"required"`
+	Email       string            `json:"email" validate:"required,email"`
+	Preferences []PreferenceInput `json:"preferences" validate:"required,dive"`
 }
 
 type LoginRequest struct {
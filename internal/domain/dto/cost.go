@@ -0,0 +1,50 @@
+package dto
+
+// CostAmount is a parsed cost. A single price has Min equal to Max.
+// Currency is empty when the text did not name one. PerPerson is set when
+// the text priced a single traveler rather than the whole party.
+type CostAmount struct {
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Currency  string  `json:"currency,omitempty"`
+	PerPerson bool    `json:"per_person,omitempty"`
+}
+
+// CostItem is one cost field of an itinerary. Amount is nil when the text
+// could not be understood.
+type CostItem struct {
+	Path     string      `json:"path"`
+	Category string      `json:"category"`
+	Raw      string      `json:"raw"`
+	Amount   *CostAmount `json:"amount,omitempty"`
+}
+
+type CostRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+type CostBudget struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// CostBreakdown totals an itinerary's costs for the whole party in a single
+// currency.
+type CostBreakdown struct {
+	Currency   string               `json:"currency"`
+	Categories map[string]CostRange `json:"categories"`
+	Total      CostRange            `json:"total"`
+	// Stated is the model's own totalCost, for comparison.
+	Stated *CostAmount `json:"stated,omitempty"`
+	Budget *CostBudget `json:"budget,omitempty"`
+	// BudgetStatus is "over" when even the cheapest reading exceeds the
+	// budget and "at_risk" when only the upper end of a range does.
+	BudgetStatus string  `json:"budget_status,omitempty"`
+	Overrun      float64 `json:"overrun,omitempty"`
+	// Unparsed and Unconverted list the paths of costs left out of the
+	// totals, either because the text was not understood or because there
+	// is no exchange rate for their currency.
+	Unparsed    []string `json:"unparsed"`
+	Unconverted []string `json:"unconverted"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type ExchangeRatesResponse struct {
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`
+	UpdatedAt *time.Time         `json:"updated_at"`
+}
+
+// UpdateExchangeRatesRequest sets the listed rates and leaves the others
+// untouched. Rates are units of the currency per unit of the base currency.
+type UpdateExchangeRatesRequest struct {
+	Base  string             `json:"base" validate:"omitempty,iso4217"`
+	Rates map[string]float64 `json:"rates" validate:"required,min=1,dive,keys,iso4217,endkeys,gt=0"`
+}
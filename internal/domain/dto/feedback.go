@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// FeedbackRequest rates a trip, or the day or activity addressed by Day and
+// Activity, with a thumbs up (1) or down (-1). Indexes are zero-based like
+// the trip's JSON arrays.
+type FeedbackRequest struct {
+	Day      *int     `json:"-"`
+	Activity *int     `json:"-"`
+	Rating   int      `json:"rating" validate:"required,oneof=1 -1"`
+	Reasons  []string `json:"reasons" validate:"omitempty,max=10,unique,dive,oneof=great_value well_paced good_food hidden_gems matches_preferences too_expensive too_packed too_relaxed too_much_travel not_my_style inaccurate_info place_closed_or_missing unsafe"`
+	Comment  string   `json:"comment" validate:"max=1000"`
+}
+
+type FeedbackResponse struct {
+	ID            string     `json:"id"`
+	Level         string     `json:"level"`
+	Day           *int       `json:"day,omitempty"`
+	Activity      *int       `json:"activity,omitempty"`
+	Revision      int        `json:"revision"`
+	Rating        int        `json:"rating"`
+	Reasons       []string   `json:"reasons"`
+	Comment       *string    `json:"comment,omitempty"`
+	PromptVersion *string    `json:"prompt_version,omitempty"`
+	Model         *string    `json:"model,omitempty"`
+	CreatedAt     *time.Time `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+}
+
+type GetFeedbackReportRequest struct {
+	Level string `query:"level" validate:"omitempty,oneof=trip day activity"`
+	Since string `query:"since" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// FeedbackReportResponse aggregates the ratings given to trips produced by
+// one model and prompt template version. Approval is the share of ratings
+// that are a thumbs up.
+type FeedbackReportResponse struct {
+	Model         string         `json:"model"`
+	PromptVersion string         `json:"prompt_version"`
+	Count         int            `json:"count"`
+	Up            int            `json:"up"`
+	Down          int            `json:"down"`
+	Approval      float64        `json:"approval"`
+	Reasons       map[string]int `json:"reasons"`
+}
@@ -0,0 +1,70 @@
+package dto
+
+type TripBudget struct {
+	Amount   float64 `json:"amount" validate:"gt=0"`
+	Currency string  `json:"currency" validate:"required,iso4217"`
+}
+
+type GenerateTripRequest struct {
+	Destinations  []string    `json:"destinations" validate:"required_without=Text,omitempty,max=5,dive,required,max=100"`
+	StartDate     string      `json:"start_date" validate:"required_with=EndDate,omitempty,datetime=2006-01-02"`
+	EndDate       string      `json:"end_date" validate:"required_with=StartDate,omitempty,datetime=2006-01-02"`
+	Duration      int         `json:"duration" validate:"omitempty,min=1,max=30"`
+	Travelers     int         `json:"travelers" validate:"omitempty,min=1,max=50"`
+	TravelerAges  []int       `json:"traveler_ages" validate:"omitempty,max=50,dive,min=0,max=120"`
+	Budget        *TripBudget `json:"budget"`
+	Pace          string      `json:"pace" validate:"omitempty,oneof=relaxed balanced packed"`
+	MustSee       []string    `json:"must_see" validate:"omitempty,max=20,dive,required,max=200"`
+	Text          string      `json:"text" validate:"required_without=Destinations,max=2000"`
+	UsePreference *bool       `json:"use_preference"`
+	// Language is the BCP 47 tag the itinerary is written in. It defaults to
+	// the language in the user's profile.
+	Language string `json:"language" validate:"omitempty,bcp47_language_tag"`
+	// NoCache skips the generation cache and always calls the model.
+	NoCache bool `json:"no_cache"`
+}
+
+// PreferencesEnabled reports whether the user's profile and preferences
+// should shape the prompt. It defaults to true when the field is omitted.
+func (r *GenerateTripRequest) PreferencesEnabled() bool {
+	return r.UsePreference == nil || *r.UsePreference
+}
+
+// UserPlaces returns the destinations and must-see places, which end up
+// verbatim in the prompt next to Text.
+func (r *GenerateTripRequest) UserPlaces() []string {
+	places := make([]string, 0, len(r.Destinations)+len(r.MustSee))
+	places = append(places, r.Destinations...)
+	return append(places, r.MustSee...)
+}
+
+type GenerateVariantsRequest struct {
+	GenerateTripRequest
+	Variants []string `json:"variants" validate:"omitempty,min=2,max=4,unique,dive,oneof=budget balanced premium relaxed packed"`
+}
+
+type TripComparison struct {
+	TotalCost      string   `json:"total_cost"`
+	TotalCostValue *float64 `json:"total_cost_value,omitempty"`
+	ActivityCount  int      `json:"activity_count"`
+	TravelMinutes  int      `json:"travel_minutes"`
+}
+
+type TripVariantResponse struct {
+	ID         string                 `json:"id"`
+	Variant    string                 `json:"variant"`
+	Trip       map[string]interface{} `json:"trip,omitempty"`
+	Comparison *TripComparison        `json:"comparison,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+type GenerateVariantsResponse struct {
+	GroupID  string                `json:"group_id"`
+	Variants []TripVariantResponse `json:"variants"`
+}
+
+type GenerationCacheStatsResponse struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+}
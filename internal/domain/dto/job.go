@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type GenerationJobResponse struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	TripID     *string    `json:"trip_id,omitempty"`
+	Error      *string    `json:"error,omitempty"`
+	CreatedAt  *time.Time `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
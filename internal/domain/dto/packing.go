@@ -0,0 +1,36 @@
+package dto
+
+type PackingItemResponse struct {
+	ID       string `json:"id"`
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	Checked  bool   `json:"checked"`
+	Custom   bool   `json:"custom"`
+}
+
+type PackingCategoryResponse struct {
+	Category string                `json:"category"`
+	Items    []PackingItemResponse `json:"items"`
+}
+
+type PackingListResponse struct {
+	TripID     string                    `json:"trip_id"`
+	Packed     int                       `json:"packed"`
+	Total      int                       `json:"total"`
+	Categories []PackingCategoryResponse `json:"categories"`
+}
+
+type AddPackingItemRequest struct {
+	Category string `json:"category" validate:"required,oneof=documents clothing toiletries health electronics gear other"`
+	Name     string `json:"name" validate:"required,max=255"`
+	Quantity int    `json:"quantity" validate:"omitempty,min=1,max=99"`
+}
+
+// UpdatePackingItemRequest changes only the fields that are present.
+type UpdatePackingItemRequest struct {
+	Category *string `json:"category" validate:"omitempty,oneof=documents clothing toiletries health electronics gear other"`
+	Name     *string `json:"name" validate:"omitempty,min=1,max=255"`
+	Quantity *int    `json:"quantity" validate:"omitempty,min=1,max=99"`
+	Checked  *bool   `json:"checked"`
+}
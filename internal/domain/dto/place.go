@@ -0,0 +1,47 @@
+package dto
+
+import "mime/multipart"
+
+// ImportPlacesRequest uploads an OSM extract as CSV or GeoJSON.
+type ImportPlacesRequest struct {
+	File *multipart.FileHeader `form:"file" validate:"required"`
+}
+
+type ImportPlacesResponse struct {
+	Imported int   `json:"imported"`
+	Skipped  int   `json:"skipped"`
+	Total    int64 `json:"total"`
+}
+
+type PlaceResponse struct {
+	ID           string  `json:"id"`
+	OsmID        string  `json:"osm_id"`
+	Name         string  `json:"name"`
+	Category     *string `json:"category"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	OpeningHours *string `json:"opening_hours"`
+	Address      *string `json:"address"`
+	City         *string `json:"city"`
+}
+
+// ActivityPlaceResponse is the place an activity was matched to. Place is
+// nil and PossiblyHallucinated is set when nothing in the dataset looks
+// like the activity's location.
+type ActivityPlaceResponse struct {
+	Day                  int            `json:"day"`
+	Activity             int            `json:"activity"`
+	Title                string         `json:"title"`
+	Location             string         `json:"location"`
+	Address              string         `json:"address"`
+	Place                *PlaceResponse `json:"place"`
+	Score                float64        `json:"score"`
+	PossiblyHallucinated bool           `json:"possibly_hallucinated"`
+}
+
+type TripPlacesResponse struct {
+	TripID     string                  `json:"trip_id"`
+	Matched    int                     `json:"matched"`
+	Unmatched  int                     `json:"unmatched"`
+	Activities []ActivityPlaceResponse `json:"activities"`
+}
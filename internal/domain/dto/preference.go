@@ -0,0 +1,31 @@
+package dto
+
+type PreferenceTagResponse struct {
+	Slug  string `json:"slug"`
+	Label string `json:"label"`
+	Icon  string `json:"icon"`
+}
+
+type PreferenceCategoryResponse struct {
+	Slug  string                  `json:"slug"`
+	Label string                  `json:"label"`
+	Icon  string                  `json:"icon"`
+	Tags  []PreferenceTagResponse `json:"tags"`
+}
+
+type ImportPreferenceTag struct {
+	Slug   string            `json:"slug" validate:"required,max=100"`
+	Labels map[string]string `json:"labels" validate:"required,min=1"`
+	Icon   string            `json:"icon" validate:"omitempty,max=255"`
+}
+
+type ImportPreferenceCategory struct {
+	Slug   string                `json:"slug" validate:"required,max=100"`
+	Labels map[string]string     `json:"labels" validate:"required,min=1"`
+	Icon   string                `json:"icon" validate:"omitempty,max=255"`
+	Tags   []ImportPreferenceTag `json:"tags" validate:"required,min=1,dive"`
+}
+
+type ImportPreferenceCatalogRequest struct {
+	Categories []ImportPreferenceCategory `json:"categories" validate:"required,min=1,dive"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+type TasteCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// TasteProfileResponse summarizes what a user's past trips and feedback say
+// about their taste. Lists are ordered from most to least relevant.
+type TasteProfileResponse struct {
+	TripCount    int          `json:"trip_count"`
+	FavoriteTags []TasteCount `json:"favorite_tags"`
+	BudgetTiers  []TasteCount `json:"budget_tiers"`
+	Destinations []string     `json:"destinations"`
+	// VisitedPlaces are activity locations of trips that have ended and that
+	// the user gave feedback on afterwards.
+	VisitedPlaces  []string     `json:"visited_places"`
+	LikedPlaces    []string     `json:"liked_places"`
+	DislikedPlaces []string     `json:"disliked_places"`
+	Complaints     []TasteCount `json:"complaints"`
+}
+
+// Empty reports whether there is no history to learn from yet.
+func (t *TasteProfileResponse) Empty() bool {
+	return len(t.FavoriteTags) == 0 && len(t.BudgetTiers) == 0 && len(t.Destinations) == 0 &&
+		len(t.VisitedPlaces) == 0 && len(t.LikedPlaces) == 0 && len(t.DislikedPlaces) == 0 && len(t.Complaints) == 0
+}
@@ -1,5 +1,10 @@
 package dto
 
+import (
+	"strconv"
+	"time"
+)
+
 type TripSummaryResponse struct {
 	ID          string `json:"id"`
 	Title       string `json:"title"`
@@ -7,4 +12,74 @@ type TripSummaryResponse struct {
 	StartDate   string `json:"start_date"`
 	EndDate     string `json:"end_date"`
 	Travelers   int    `json:"travelers"`
+	Language    string `json:"language,omitempty"`
+}
+
+type RefineTripRequest struct {
+	Instruction string `json:"instruction" validate:"required,max=1000"`
+}
+
+// RegenerateTripRequest addresses days[Day], or one activity or meal within
+// it. Indexes are zero-based like the trip's JSON arrays.
+type RegenerateTripRequest struct {
+	Day         int    `json:"-"`
+	Activity    *int   `json:"-"`
+	Meal        string `json:"-" validate:"omitempty,oneof=breakfast lunch dinner"`
+	Instruction string `json:"instruction" validate:"max=1000"`
+}
+
+// Path renders the addressed fragment the way it appears in trip diffs.
+func (r *RegenerateTripRequest) Path() string {
+	path := "days[" + strconv.Itoa(r.Day) + "]"
+
+	switch {
+	case r.Activity != nil:
+		path += ".activities[" + strconv.Itoa(*r.Activity) + "]"
+	case r.Meal != "":
+		path += ".meals." + r.Meal
+	}
+
+	return path
+}
+
+type TranslateTripRequest struct {
+	Language string `json:"language" validate:"required,bcp47_language_tag"`
+}
+
+type RefineTripResponse struct {
+	ID       string                 `json:"id"`
+	Revision int                    `json:"revision"`
+	Trip     map[string]interface{} `json:"trip"`
+	Changes  []TripChangeResponse   `json:"changes"`
+}
+
+// TripChangeResponse is one leaf that differs between two revisions. Path
+// uses dots for object keys and brackets for array indexes, e.g.
+// "days[1].activities[0].cost"; Op is added, removed or changed.
+type TripChangeResponse struct {
+	Path   string      `json:"path"`
+	Op     string      `json:"op"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+type TripRevisionResponse struct {
+	Number        int        `json:"number"`
+	Instruction   *string    `json:"instruction,omitempty"`
+	PromptVersion *string    `json:"prompt_version,omitempty"`
+	CreatedAt     *time.Time `json:"created_at"`
+}
+
+// TripCostsResponse is the cost breakdown of a trip along with every cost
+// field as it was parsed.
+type TripCostsResponse struct {
+	*CostBreakdown
+	Items []CostItem `json:"items"`
+}
+
+type TripMessageResponse struct {
+	Role      string     `json:"role"`
+	Content   string     `json:"content"`
+	Revision  int        `json:"revision"`
+	CreatedAt *time.Time `json:"created_at"`
 }
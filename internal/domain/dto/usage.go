@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type UsageWindowResponse struct {
+	// Limit is the token allowance for the window, 0 means unlimited.
+	Limit          int       `json:"limit"`
+	Used           int       `json:"used"`
+	Remaining      *int      `json:"remaining,omitempty"`
+	PromptTokens   int       `json:"prompt_tokens"`
+	ResponseTokens int       `json:"response_tokens"`
+	Requests       int       `json:"requests"`
+	ResetAt        time.Time `json:"reset_at"`
+}
+
+type UsageResponse struct {
+	Role    string              `json:"role"`
+	Daily   UsageWindowResponse `json:"daily"`
+	Monthly UsageWindowResponse `json:"monthly"`
+}
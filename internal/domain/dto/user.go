@@ -1,21 +1,80 @@
 package dto
 
-import "mime/multipart"
+import (
+	"encoding/json"
+	"mime/multipart"
+)
 
 type GetProfileResponse struct {
-	Name        string   `json:"name"`
-	Email       string   `json:"email"`
-	PhotoURL    string   `json:"photo_url"`
-	Preferences []string `json:"preferences"`
+	Name                string               `json:"name"`
+	Email               string               `json:"email"`
+	PhotoURL            string               `json:"photo_url"`
+	PhotoVariants       map[string]string    `json:"photo_variants"`
+	HomeCity            string               `json:"home_city"`
+	HomeCountry         string               `json:"home_country"`
+	Currency            string               `json:"currency"`
+	Language            string               `json:"language"`
+	DietaryRestrictions []string             `json:"dietary_restrictions"`
+	MobilityNeeds       string               `json:"mobility_needs"`
+	BudgetTier          string               `json:"budget_tier"`
+	TravelStyle         string               `json:"travel_style"`
+	Preferences         []PreferenceResponse `json:"preferences"`
 }
 
+// EditProfileRequest changes only the fields that are sent. Sending an empty
+// value clears the field.
 type EditProfileRequest struct {
-	Name            string                `form:"name"`
-	CurrentPassword string                `form:"current_password" validate:"required_with=NewPassword,omitempty,min=6"`
-	NewPassword     string                `form:"new_password" validate:"omitempty,min=6"`
-	Photo           *multipart.FileHeader `form:"photo"`
+	Name                *string               `form:"name" validate:"omitempty,min=1,max=255"`
+	CurrentPassword     string                `form:"current_password" validate:"required_with=NewPassword,omitempty,min=6"`
+	NewPassword         string                `form:"new_password" validate:"omitempty,min=6"`
+	Photo               *multipart.FileHeader `form:"photo"`
+	HomeCity            *string               `form:"home_city" validate:"omitempty,max=255"`
+	HomeCountry         *string               `form:"home_country" validate:"omitempty,max=255"`
+	Currency            *string               `form:"currency" validate:"omitempty,len=0|iso4217"`
+	Language            *string               `form:"language" validate:"omitempty,len=0|bcp47_language_tag"`
+	DietaryRestrictions *string               `form:"dietary_restrictions" validate:"omitempty,max=255"`
+	MobilityNeeds       *string               `form:"mobility_needs" validate:"omitempty,max=255"`
+	BudgetTier          *string               `form:"budget_tier" validate:"omitempty,oneof='' budget moderate luxury"`
+	TravelStyle         *string               `form:"travel_style" validate:"omitempty,oneof='' relaxed balanced adventurous cultural luxury backpacker family"`
+}
+
+type PreferenceResponse struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	Weight   string `json:"weight"`
+	Polarity string `json:"polarity"`
+}
+
+// PreferenceInput accepts either a bare tag slug ("museum") or an object with
+// an explicit weight and polarity.
+type PreferenceInput struct {
+	Name     string `json:"name" validate:"required"`
+	Weight   string `json:"weight" validate:"omitempty,oneof=mild moderate strong"`
+	Polarity string `json:"polarity" validate:"omitempty,oneof=like dislike"`
+}
+
+func (p *PreferenceInput) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		p.Name = name
+		return nil
+	}
+
+	type preferenceInput PreferenceInput
+	var input preferenceInput
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+
+	*p = PreferenceInput(input)
+	return nil
 }
 
 type AddPreferenceRequest struct {
-	Preferences []string `json:"preferences" validate:"required"`
+	Preferences []PreferenceInput `json:"preferences" validate:"required,dive"`
+}
+
+type UpdatePreferenceRequest struct {
+	Add    []PreferenceInput `json:"add" validate:"required_without=Remove,dive"`
+	Remove []string          `json:"remove" validate:"required_without=Add,dive,required"`
 }
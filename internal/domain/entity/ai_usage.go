@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AIUsage records the tokens spent by one model call on behalf of a user.
+type AIUsage struct {
+	ID             uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	UserID         uuid.UUID  `gorm:"column:user_id;type:char(36);not null;index:idx_ai_usage_user_created"`
+	User           *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Provider       string     `gorm:"column:provider;type:varchar(20);not null"`
+	Model          string     `gorm:"column:model;type:varchar(100)"`
+	PromptTokens   int        `gorm:"column:prompt_tokens;type:int;not null;default:0"`
+	ResponseTokens int        `gorm:"column:response_tokens;type:int;not null;default:0"`
+	CreatedAt      *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;index:idx_ai_usage_user_created"`
+}
+
+func (u *AIUsage) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	u.ID = id
+	return
+}
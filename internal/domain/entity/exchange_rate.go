@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// ExchangeRate is how many units of Currency one unit of the base currency
+// (cost.BaseCurrency) buys. The table is maintained by admins so budget
+// conversion works without calling an external service.
+type ExchangeRate struct {
+	Currency  string     `gorm:"column:currency;type:char(3);primaryKey;not null"`
+	Rate      float64    `gorm:"column:rate;type:double precision;not null"`
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"apac/internal/domain/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+type GenerationJob struct {
+	ID        uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	UserID    uuid.UUID  `gorm:"column:user_id;type:char(36);not null;index"`
+	User      *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Status    string     `gorm:"column:status;type:varchar(20);not null;index"`
+	Request   string     `gorm:"column:request;type:jsonb;not null"`
+	TripID    *uuid.UUID `gorm:"column:trip_id;type:char(36)"`
+	Error     *string    `gorm:"column:error;type:text"`
+	Attempts  int        `gorm:"column:attempts;type:int;not null;default:0"`
+	StartedAt *time.Time `gorm:"column:started_at;type:timestamp"`
+	// AvailableAt is when a queued job may be claimed, which lets retries
+	// back off, and when the lease of a running job runs out. A running job
+	// past its lease belongs to a worker that died and can be claimed again.
+	AvailableAt *time.Time `gorm:"column:available_at;type:timestamp;index"`
+	FinishedAt  *time.Time `gorm:"column:finished_at;type:timestamp"`
+	CreatedAt   *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt   *time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (j *GenerationJob) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	j.ID = id
+	return
+}
+
+func (j *GenerationJob) ParseDTOGet() dto.GenerationJobResponse {
+	resp := dto.GenerationJobResponse{
+		ID:         j.ID.String(),
+		Status:     j.Status,
+		Error:      j.Error,
+		CreatedAt:  j.CreatedAt,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+	}
+
+	if j.TripID != nil {
+		tripID := j.TripID.String()
+		resp.TripID = &tripID
+	}
+
+	return resp
+}
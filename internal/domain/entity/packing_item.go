@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"apac/internal/domain/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PackingItem is one line of a trip's packing checklist. Custom items were
+// added or edited by the user and survive regenerating the list.
+type PackingItem struct {
+	ID        uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	TripID    uuid.UUID  `gorm:"column:trip_id;type:char(36);not null;index"`
+	Trip      *Trip      `gorm:"foreignKey:TripID;constraint:OnDelete:CASCADE"`
+	Category  string     `gorm:"column:category;type:varchar(20);not null"`
+	Name      string     `gorm:"column:name;type:varchar(255);not null"`
+	Quantity  int        `gorm:"column:quantity;type:int;not null;default:1"`
+	Position  int        `gorm:"column:position;type:int;not null;default:0"`
+	Checked   bool       `gorm:"column:checked;not null;default:false"`
+	Custom    bool       `gorm:"column:custom;not null;default:false"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (i *PackingItem) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	i.ID = id
+	return
+}
+
+func (i *PackingItem) ParseDTOGet() dto.PackingItemResponse {
+	return dto.PackingItemResponse{
+		ID:       i.ID.String(),
+		Category: i.Category,
+		Name:     i.Name,
+		Quantity: i.Quantity,
+		Checked:  i.Checked,
+		Custom:   i.Custom,
+	}
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"apac/internal/domain/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Place is a point of interest imported from an OSM extract. Activity
+// locations are matched against NormalizedName, which has a trigram index
+// created in the migration. OSM puts no limit on tag values, so the free-form
+// ones are stored as text.
+type Place struct {
+	ID             uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	OsmID          string     `gorm:"column:osm_id;type:varchar(300);uniqueIndex;not null"`
+	Name           string     `gorm:"column:name;type:varchar(255);not null"`
+	NormalizedName string     `gorm:"column:normalized_name;type:varchar(255);index;not null"`
+	Category       *string    `gorm:"column:category;type:varchar(100)"`
+	Latitude       float64    `gorm:"column:latitude;type:double precision;not null"`
+	Longitude      float64    `gorm:"column:longitude;type:double precision;not null"`
+	OpeningHours   *string    `gorm:"column:opening_hours;type:text"`
+	Address        *string    `gorm:"column:address;type:text"`
+	City           *string    `gorm:"column:city;type:varchar(255)"`
+	CreatedAt      *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt      *time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (p *Place) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	p.ID = id
+	return
+}
+
+func (p *Place) ParseDTOGet() dto.PlaceResponse {
+	return dto.PlaceResponse{
+		ID:           p.ID.String(),
+		OsmID:        p.OsmID,
+		Name:         p.Name,
+		Category:     p.Category,
+		Latitude:     p.Latitude,
+		Longitude:    p.Longitude,
+		OpeningHours: p.OpeningHours,
+		Address:      p.Address,
+		City:         p.City,
+	}
+}
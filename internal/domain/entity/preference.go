@@ -7,17 +7,63 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	PolarityLike    = "like"
+	PolarityDislike = "dislike"
+
+	WeightMild     = 1
+	WeightModerate = 2
+	WeightStrong   = 3
+)
+
+var weightNames = map[int]string{
+	WeightMild:     "mild",
+	WeightModerate: "moderate",
+	WeightStrong:   "strong",
+}
+
 type Preference struct {
-	ID        uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
-	Name      string     `gorm:"column:name;type:varchar(255);not null"`
-	UserID    uuid.UUID  `gorm:"column:user_id;type:char(36);not null"`
-	User      *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
-	CreatedAt *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
-	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+	ID        uuid.UUID      `gorm:"column:id;type:char(36);primaryKey;not null"`
+	Name      string         `gorm:"column:name;type:varchar(255);not null"`
+	TagID     *uuid.UUID     `gorm:"column:tag_id;type:char(36)"`
+	Tag       *PreferenceTag `gorm:"foreignKey:TagID;constraint:OnDelete:CASCADE"`
+	Weight    int            `gorm:"column:weight;type:smallint;not null;default:2"`
+	Polarity  string         `gorm:"column:polarity;type:varchar(10);not null;default:like"`
+	UserID    uuid.UUID      `gorm:"column:user_id;type:char(36);not null"`
+	User      *User          `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	CreatedAt *time.Time     `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt *time.Time     `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
 }
 
 func (p *Preference) BeforeCreate(tx *gorm.DB) (err error) {
 	id, _ := uuid.NewV7()
 	p.ID = id
+
+	if p.Weight == 0 {
+		p.Weight = WeightModerate
+	}
+
+	if p.Polarity == "" {
+		p.Polarity = PolarityLike
+	}
+
 	return
 }
+
+func (p *Preference) WeightName() string {
+	if name, ok := weightNames[p.Weight]; ok {
+		return name
+	}
+
+	return weightNames[WeightModerate]
+}
+
+func ParseWeight(name string) int {
+	for weight, n := range weightNames {
+		if n == name {
+			return weight
+		}
+	}
+
+	return WeightModerate
+}
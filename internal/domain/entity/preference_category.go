@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PreferenceCategory struct {
+	ID        uuid.UUID       `gorm:"column:id;type:char(36);primaryKey;not null"`
+	Slug      string          `gorm:"column:slug;type:varchar(100);unique;not null"`
+	Labels    string          `gorm:"column:labels;type:jsonb;not null"`
+	Icon      string          `gorm:"column:icon;type:varchar(255)"`
+	Position  int             `gorm:"column:position;type:int;default:0"`
+	Tags      []PreferenceTag `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE"`
+	CreatedAt *time.Time      `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt *time.Time      `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (c *PreferenceCategory) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	c.ID = id
+	return
+}
+
+func (c *PreferenceCategory) Label(lang string) string {
+	return localize(c.Labels, lang, c.Slug)
+}
+
+// localize picks the label for lang from a jsonb map of language to label,
+// falling back to English and then to the slug.
+func localize(labels string, lang string, fallback string) string {
+	values := make(map[string]string)
+	json.Unmarshal([]byte(labels), &values)
+
+	if label, ok := values[lang]; ok && label != "" {
+		return label
+	}
+
+	if label, ok := values["en"]; ok && label != "" {
+		return label
+	}
+
+	return fallback
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PreferenceTag struct {
+	ID         uuid.UUID           `gorm:"column:id;type:char(36);primaryKey;not null"`
+	CategoryID uuid.UUID           `gorm:"column:category_id;type:char(36);not null"`
+	Category   *PreferenceCategory `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE"`
+	Slug       string              `gorm:"column:slug;type:varchar(100);unique;not null"`
+	Labels     string              `gorm:"column:labels;type:jsonb;not null"`
+	Icon       string              `gorm:"column:icon;type:varchar(255)"`
+	Position   int                 `gorm:"column:position;type:int;default:0"`
+	CreatedAt  *time.Time          `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt  *time.Time          `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (t *PreferenceTag) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	t.ID = id
+	return
+}
+
+func (t *PreferenceTag) Label(lang string) string {
+	return localize(t.Labels, lang, t.Slug)
+}
+
+func NormalizeSlug(slug string) string {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	slug = strings.Join(strings.Fields(slug), "-")
+	return strings.ReplaceAll(slug, "_", "-")
+}
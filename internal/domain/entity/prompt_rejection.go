@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"apac/internal/domain/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PromptRejection records user input the prompt guard refused to send to
+// the model, for later abuse review.
+type PromptRejection struct {
+	ID        uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	UserID    uuid.UUID  `gorm:"column:user_id;type:char(36);not null;index"`
+	User      *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Source    string     `gorm:"column:source;type:varchar(50);not null"`
+	Reason    string     `gorm:"column:reason;type:varchar(20);not null;index"`
+	Detail    string     `gorm:"column:detail;type:text;not null"`
+	Input     string     `gorm:"column:input;type:text;not null"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;index"`
+}
+
+func (r *PromptRejection) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	r.ID = id
+	return
+}
+
+func (r *PromptRejection) ParseDTOGet() dto.PromptRejectionResponse {
+	return dto.PromptRejectionResponse{
+		ID:        r.ID.String(),
+		UserID:    r.UserID.String(),
+		Source:    r.Source,
+		Reason:    r.Reason,
+		Detail:    r.Detail,
+		Input:     r.Input,
+		CreatedAt: r.CreatedAt,
+	}
+}
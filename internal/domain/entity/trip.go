@@ -1,25 +1,93 @@
 package entity
 
 import (
+	"apac/internal/domain/dto"
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type Trip struct {
-	ID      uuid.UUID `gorm:"column:id;type:char(36);primaryKey;not null"`
-	UserID  uuid.UUID `gorm:"column:user_id;type:char(36);not null"`
-	Content string    `gorm:"column:content;type:jsonb;not null"`
-	User    *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	ID       uuid.UUID `gorm:"column:id;type:char(36);primaryKey;not null"`
+	UserID   uuid.UUID `gorm:"column:user_id;type:char(36);not null"`
+	Content  string    `gorm:"column:content;type:jsonb;not null"`
+	Revision int       `gorm:"column:revision;type:int;not null;default:1"`
+	// Variants generated side by side share a group and stay drafts until
+	// the user keeps one of them.
+	VariantGroupID *uuid.UUID `gorm:"column:variant_group_id;type:char(36);index"`
+	Variant        *string    `gorm:"column:variant;type:varchar(20)"`
+	Draft          bool       `gorm:"column:draft;not null;default:false"`
+	// PromptVersion names the template that produced the content, e.g. "trip/v1".
+	PromptVersion *string `gorm:"column:prompt_version;type:varchar(50)"`
+	Language      *string `gorm:"column:language;type:varchar(35)"`
+	// Model is the "<provider>/<model>" that generated the trip.
+	Model *string `gorm:"column:model;type:varchar(100)"`
+	// SourceID points at the trip this one was translated from.
+	SourceID *uuid.UUID `gorm:"column:source_id;type:char(36)"`
+	// The budget the trip was requested with, if any.
+	BudgetAmount   *float64 `gorm:"column:budget_amount;type:numeric(14,2)"`
+	BudgetCurrency *string  `gorm:"column:budget_currency;type:char(3)"`
+	// Costs holds the itinerary's cost fields, parsed whenever the content
+	// is saved.
+	Costs     *string    `gorm:"column:costs;type:jsonb"`
+	User      *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
 }
 
 func (t *Trip) BeforeCreate(tx *gorm.DB) (err error) {
 	id, _ := uuid.NewV7()
 	t.ID = id
+	if t.Revision == 0 {
+		t.Revision = 1
+	}
 	return
 }
 
+// SetContent stores content as the itinerary along with its parsed costs.
+func (t *Trip) SetContent(content map[string]interface{}, items []dto.CostItem) error {
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	costs, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	t.Content = string(encoded)
+	parsed := string(costs)
+	t.Costs = &parsed
+
+	return nil
+}
+
+// CostItems returns the parsed costs. It reports false for trips saved
+// before costs were recorded, whose content has to be parsed instead.
+func (t *Trip) CostItems() ([]dto.CostItem, bool) {
+	if t.Costs == nil {
+		return nil, false
+	}
+
+	var items []dto.CostItem
+	if err := json.Unmarshal([]byte(*t.Costs), &items); err != nil {
+		return nil, false
+	}
+
+	return items, true
+}
+
+func (t *Trip) Budget() *dto.CostBudget {
+	if t.BudgetAmount == nil || t.BudgetCurrency == nil {
+		return nil
+	}
+
+	return &dto.CostBudget{Amount: *t.BudgetAmount, Currency: *t.BudgetCurrency}
+}
+
 func (t *Trip) ParseDTOGet() map[string]interface{} {
 	tripResponse := make(map[string]interface{})
 
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"apac/internal/domain/dto"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	FeedbackTrip     = "trip"
+	FeedbackDay      = "day"
+	FeedbackActivity = "activity"
+
+	RatingUp   = 1
+	RatingDown = -1
+)
+
+// TripFeedback is a user's thumbs up or down, stored as RatingUp or
+// RatingDown, on a whole trip, one of its days or one activity. The prompt version and model are copied from the trip so the
+// ratings can be compared across them after the trip is gone; deleting the
+// trip only clears TripID. Day and Activity index into the content of the
+// rated Revision. A user has one rating per target and revision, enforced by
+// a unique index created in the migration.
+type TripFeedback struct {
+	ID            uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	TripID        *uuid.UUID `gorm:"column:trip_id;type:char(36);index"`
+	Trip          *Trip      `gorm:"foreignKey:TripID;constraint:OnDelete:SET NULL"`
+	UserID        uuid.UUID  `gorm:"column:user_id;type:char(36);not null"`
+	User          *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Level         string     `gorm:"column:level;type:varchar(10);not null"`
+	Day           *int       `gorm:"column:day;type:int"`
+	Activity      *int       `gorm:"column:activity;type:int"`
+	Revision      int        `gorm:"column:revision;type:int;not null"`
+	Rating        int        `gorm:"column:rating;type:int;not null"`
+	Reasons       string     `gorm:"column:reasons;type:jsonb;not null;default:'[]'"`
+	Comment       *string    `gorm:"column:comment;type:text"`
+	PromptVersion *string    `gorm:"column:prompt_version;type:varchar(50);index"`
+	Model         *string    `gorm:"column:model;type:varchar(100);index"`
+	CreatedAt     *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt     *time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (f *TripFeedback) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	f.ID = id
+	return
+}
+
+func (f *TripFeedback) ParseDTOGet() dto.FeedbackResponse {
+	reasons := make([]string, 0)
+	json.Unmarshal([]byte(f.Reasons), &reasons)
+
+	return dto.FeedbackResponse{
+		ID:            f.ID.String(),
+		Level:         f.Level,
+		Day:           f.Day,
+		Activity:      f.Activity,
+		Revision:      f.Revision,
+		Rating:        f.Rating,
+		Reasons:       reasons,
+		Comment:       f.Comment,
+		PromptVersion: f.PromptVersion,
+		Model:         f.Model,
+		CreatedAt:     f.CreatedAt,
+		UpdatedAt:     f.UpdatedAt,
+	}
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"apac/internal/domain/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	MessageUser      = "user"
+	MessageAssistant = "assistant"
+)
+
+// TripMessage is one turn of the refinement conversation held about a trip.
+type TripMessage struct {
+	ID        uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	TripID    uuid.UUID  `gorm:"column:trip_id;type:char(36);not null;index"`
+	Trip      *Trip      `gorm:"foreignKey:TripID;constraint:OnDelete:CASCADE"`
+	Role      string     `gorm:"column:role;type:varchar(20);not null"`
+	Content   string     `gorm:"column:content;type:text;not null"`
+	Revision  int        `gorm:"column:revision;type:int;not null"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+}
+
+func (m *TripMessage) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	m.ID = id
+	return
+}
+
+func (m *TripMessage) ParseDTOGet() dto.TripMessageResponse {
+	return dto.TripMessageResponse{
+		Role:      m.Role,
+		Content:   m.Content,
+		Revision:  m.Revision,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"apac/internal/domain/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TripRevision keeps every version of a trip's itinerary. Revision 1 is the
+// generated trip, later revisions come from refinements.
+type TripRevision struct {
+	ID            uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	TripID        uuid.UUID  `gorm:"column:trip_id;type:char(36);not null;uniqueIndex:idx_trip_revision"`
+	Trip          *Trip      `gorm:"foreignKey:TripID;constraint:OnDelete:CASCADE"`
+	Number        int        `gorm:"column:number;type:int;not null;uniqueIndex:idx_trip_revision"`
+	Content       string     `gorm:"column:content;type:jsonb;not null"`
+	Instruction   *string    `gorm:"column:instruction;type:text"`
+	PromptVersion *string    `gorm:"column:prompt_version;type:varchar(50)"`
+	CreatedAt     *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+}
+
+func (r *TripRevision) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	r.ID = id
+	return
+}
+
+func (r *TripRevision) ParseDTOGet() dto.TripRevisionResponse {
+	return dto.TripRevisionResponse{
+		Number:        r.Number,
+		Instruction:   r.Instruction,
+		PromptVersion: r.PromptVersion,
+		CreatedAt:     r.CreatedAt,
+	}
+}
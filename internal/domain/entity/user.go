@@ -2,45 +2,94 @@ package entity
 
 import (
 	"apac/internal/domain/dto"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
-	ID           uuid.UUID      `gorm:"column:id;type:char(36);primaryKey;not null"`
-	Email        string         `gorm:"column:email;type:varchar(255);unique;not null"`
-	Password     *string        `gorm:"column:password;type:varchar(255)"`
-	Name         string         `gorm:"column:name;type:varchar(255);not null"`
-	GoogleID     *string        `gorm:"column:google_id;type:varchar(255);unique"`
-	Verified     bool           `gorm:"column:verified;type:bool;default:false"`
-	PhotoURL     string         `gorm:"column:photo_url;type:varchar(255);not null"`
-	Preference   []Preference   `gorm:"foreignKey:user_id;constraint:OnDelete:SET NULL;"`
-	RefreshToken []RefreshToken `gorm:"foreignKey:user_id;constraint:OnUpdate:SET NULL,OnDelete:SET NULL;"`
-	CreatedAt    *time.Time     `gorm:"column:created_at;type:timestamp;autoCreateTime"`
-	UpdatedAt    *time.Time     `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+	ID                  uuid.UUID      `gorm:"column:id;type:char(36);primaryKey;not null"`
+	Email               string         `gorm:"column:email;type:varchar(255);unique;not null"`
+	Password            *string        `gorm:"column:password;type:varchar(255)"`
+	Name                string         `gorm:"column:name;type:varchar(255);not null"`
+	GoogleID            *string        `gorm:"column:google_id;type:varchar(255);unique"`
+	Verified            bool           `gorm:"column:verified;type:bool;default:false"`
+	Role                string         `gorm:"column:role;type:varchar(20);not null;default:user"`
+	PhotoURL            string         `gorm:"column:photo_url;type:varchar(255);not null"`
+	PhotoVariants       *string        `gorm:"column:photo_variants;type:jsonb"`
+	HomeCity            string         `gorm:"column:home_city;type:varchar(255)"`
+	HomeCountry         string         `gorm:"column:home_country;type:varchar(255)"`
+	Currency            string         `gorm:"column:currency;type:char(3)"`
+	Language            string         `gorm:"column:language;type:varchar(35)"`
+	DietaryRestrictions *string        `gorm:"column:dietary_restrictions;type:jsonb"`
+	MobilityNeeds       string         `gorm:"column:mobility_needs;type:varchar(255)"`
+	BudgetTier          string         `gorm:"column:budget_tier;type:varchar(20)"`
+	TravelStyle         string         `gorm:"column:travel_style;type:varchar(50)"`
+	Preference          []Preference   `gorm:"foreignKey:user_id;constraint:OnDelete:SET NULL;"`
+	RefreshToken        []RefreshToken `gorm:"foreignKey:user_id;constraint:OnUpdate:SET NULL,OnDelete:SET NULL;"`
+	CreatedAt           *time.Time     `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt           *time.Time     `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	id, _ := uuid.NewV7()
 	u.ID = id
 
+	if u.Role == "" {
+		u.Role = RoleUser
+	}
+
 	u.PhotoURL = "https://vvrzqepnkbaniugatilc.supabase.co/storage/v1/object/public/media/profiles/default_photo.jpg"
 
 	return
 }
 
 func (u *User) ParseDTOGet() dto.GetProfileResponse {
-	preferences := make([]string, 0)
+	preferences := make([]dto.PreferenceResponse, 0)
 	for _, p := range u.Preference {
-		preferences = append(preferences, p.Name)
+		label := p.Name
+		if p.Tag != nil {
+			label = p.Tag.Label(u.Language)
+		}
+
+		preferences = append(preferences, dto.PreferenceResponse{
+			Name:     p.Name,
+			Label:    label,
+			Weight:   p.WeightName(),
+			Polarity: p.Polarity,
+		})
+	}
+
+	photoVariants := make(map[string]string)
+	if u.PhotoVariants != nil {
+		json.Unmarshal([]byte(*u.PhotoVariants), &photoVariants)
+	}
+
+	dietary := make([]string, 0)
+	if u.DietaryRestrictions != nil {
+		json.Unmarshal([]byte(*u.DietaryRestrictions), &dietary)
 	}
 
 	return dto.GetProfileResponse{
-		Name:        u.Name,
-		Email:       u.Email,
-		PhotoURL:    u.PhotoURL,
-		Preferences: preferences,
+		Name:                u.Name,
+		Email:               u.Email,
+		PhotoURL:            u.PhotoURL,
+		PhotoVariants:       photoVariants,
+		HomeCity:            u.HomeCity,
+		HomeCountry:         u.HomeCountry,
+		Currency:            u.Currency,
+		Language:            u.Language,
+		DietaryRestrictions: dietary,
+		MobilityNeeds:       u.MobilityNeeds,
+		BudgetTier:          u.BudgetTier,
+		TravelStyle:         u.TravelStyle,
+		Preferences:         preferences,
 	}
 }
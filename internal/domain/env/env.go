@@ -1,6 +1,7 @@
 package env
 
 import (
+	"os"
 	"time"
 
 	"github.com/caarlos0/env"
@@ -44,8 +45,44 @@ type Env struct {
 
 	DefaultProfilePic string `env:"DEFAULT_PROFILE_PIC"`
 
+	LLMProvider         string        `env:"LLM_PROVIDER" envDefault:"gemini"`
+	LLMMaxRepairs       int           `env:"LLM_MAX_REPAIRS" envDefault:"2"`
+	LLMTimeout          time.Duration `env:"LLM_TIMEOUT" envDefault:"90s"`
+	LLMMaxRetries       int           `env:"LLM_MAX_RETRIES" envDefault:"2"`
+	LLMBreakerThreshold int           `env:"LLM_BREAKER_THRESHOLD" envDefault:"5"`
+	LLMBreakerCooldown  time.Duration `env:"LLM_BREAKER_COOLDOWN" envDefault:"30s"`
+
+	// Pins prompt template versions, e.g. "trip=v1,refine=v2". Templates
+	// that are not listed use their newest version.
+	PromptVersions string `env:"PROMPT_VERSIONS"`
+
 	GeminiAPIKey string `env:"GEMINI_API_KEY"`
 	GeminiModel  string `env:"GEMINI_MODEL"`
+
+	OpenAIBaseUrl string `env:"OPENAI_BASE_URL"`
+	OpenAIAPIKey  string `env:"OPENAI_API_KEY"`
+	OpenAIModel   string `env:"OPENAI_MODEL"`
+
+	OllamaUrl   string `env:"OLLAMA_URL"`
+	OllamaModel string `env:"OLLAMA_MODEL"`
+
+	FakeLLMFixture   string        `env:"FAKE_LLM_FIXTURE"`
+	FakeLLMLatency   time.Duration `env:"FAKE_LLM_LATENCY"`
+	FakeLLMFailure   string        `env:"FAKE_LLM_FAILURE"`
+	FakeLLMFailEvery int           `env:"FAKE_LLM_FAIL_EVERY"`
+
+	// POIDatasetPath is an OSM extract (CSV or GeoJSON) imported at startup
+	// while no places have been imported yet.
+	POIDatasetPath string `env:"POI_DATASET_PATH"`
+
+	GenerationWorkers  int           `env:"GENERATION_WORKERS" envDefault:"4"`
+	GenerationCacheTTL time.Duration `env:"GENERATION_CACHE_TTL" envDefault:"24h"`
+
+	// Token quotas per role, 0 means unlimited.
+	QuotaUserDailyTokens    int `env:"QUOTA_USER_DAILY_TOKENS"`
+	QuotaUserMonthlyTokens  int `env:"QUOTA_USER_MONTHLY_TOKENS"`
+	QuotaAdminDailyTokens   int `env:"QUOTA_ADMIN_DAILY_TOKENS"`
+	QuotaAdminMonthlyTokens int `env:"QUOTA_ADMIN_MONTHLY_TOKENS"`
 }
 
 func New() (*Env, error) {
@@ -53,6 +90,19 @@ func New() (*Env, error) {
 		panic(err)
 	}
 
+	// The .env file expands variables that are not set to "", which would
+	// override every envDefault. Empty values count as unset instead.
+	values, err := godotenv.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range values {
+		if os.Getenv(key) == "" {
+			os.Unsetenv(key)
+		}
+	}
+
 	_env := new(Env)
 	if err := env.Parse(_env); err != nil {
 		return nil, err
@@ -0,0 +1,373 @@
+package cost
+
+import (
+	"apac/internal/domain/dto"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/currency"
+)
+
+const (
+	CategoryLodging    = "lodging"
+	CategoryFood       = "food"
+	CategoryActivities = "activities"
+	CategoryTransport  = "transport"
+
+	// BaseCurrency is the currency exchange rates are quoted against.
+	BaseCurrency = "USD"
+
+	BudgetWithin = "within"
+	BudgetAtRisk = "at_risk"
+	BudgetOver   = "over"
+)
+
+// The parsed types live in the domain so trips and responses can carry them.
+type (
+	Amount    = dto.CostAmount
+	Item      = dto.CostItem
+	Range     = dto.CostRange
+	Budget    = dto.CostBudget
+	Breakdown = dto.CostBreakdown
+)
+
+// Rates holds how many units of each currency one BaseCurrency buys.
+type Rates map[string]float64
+
+// Convert changes amount from one currency into another. It reports false
+// when either currency has no rate.
+func (r Rates) Convert(amount float64, from string, to string) (float64, bool) {
+	if from == to {
+		return amount, true
+	}
+
+	rf, ok := r[from]
+	if !ok || rf <= 0 {
+		return 0, false
+	}
+
+	rt, ok := r[to]
+	if !ok || rt <= 0 {
+		return 0, false
+	}
+
+	return amount / rf * rt, true
+}
+
+type symbol struct {
+	code    string
+	pattern *regexp.Regexp
+}
+
+// symbols maps currency signs and common names to ISO codes. Longer signs
+// come first so "S$" is not read as "$".
+var symbols = []symbol{
+	sign("US$", "USD"), sign("S$", "SGD"), sign("A$", "AUD"), sign("NZ$", "NZD"), sign("HK$", "HKD"), sign("C$", "CAD"),
+	sign("RM", "MYR"), sign("Rp", "IDR"), sign("₱", "PHP"), sign("€", "EUR"), sign("£", "GBP"), sign("¥", "JPY"),
+	sign("₩", "KRW"), sign("฿", "THB"), sign("₫", "VND"), sign("₹", "INR"), sign("₺", "TRY"), sign("$", "USD"),
+	name("rupiah", "IDR"), name("ringgit", "MYR"), name("baht", "THB"), name("yen", "JPY"), name("won", "KRW"),
+	name("euros", "EUR"), name("euro", "EUR"), name("dollars", "USD"), name("dollar", "USD"), name("pounds", "GBP"),
+}
+
+// sign matches a currency sign as written. Signs that start with a letter,
+// such as "Rp", must also start a word.
+func sign(text string, code string) symbol {
+	pattern := regexp.QuoteMeta(text)
+	if first, _ := utf8.DecodeRuneInString(text); unicode.IsLetter(first) {
+		pattern = `\b` + pattern
+	}
+
+	return symbol{code: code, pattern: regexp.MustCompile(pattern)}
+}
+
+// name matches a currency name in any case, but only as a whole word, so
+// "European" is not read as euros.
+func name(text string, code string) symbol {
+	return symbol{code: code, pattern: regexp.MustCompile(`(?i)\b` + text + `\b`)}
+}
+
+var (
+	codeRe   = regexp.MustCompile(`\b[A-Z]{3}\b`)
+	numberRe = regexp.MustCompile(`(?i)(\d[\d.,]*)\s*(k|rb|ribu|jt|juta|million|mn)?\b`)
+	timesRe  = regexp.MustCompile(`(?i)^\s*[x×*]\s*\D{0,5}$`)
+	rangeRe  = regexp.MustCompile(`(?i)^\s*(-|–|—|~|to|until|sampai|hingga)\s*\D{0,5}$`)
+	freeRe   = regexp.MustCompile(`(?i)\b(free|gratis|included|no charge|complimentary)\b`)
+	personRe = regexp.MustCompile(`(?i)(\bper\s*|/\s*)(person|pax|head|orang|traveler|traveller)\b|\bpp\b`)
+)
+
+var multipliers = map[string]float64{
+	"k":       1e3,
+	"rb":      1e3,
+	"ribu":    1e3,
+	"jt":      1e6,
+	"juta":    1e6,
+	"million": 1e6,
+	"mn":      1e6,
+}
+
+// Parse reads a free-form cost such as "IDR 150,000", "$20-30", "Rp 50rb",
+// "$15 per person" or "Free". It returns nil when no amount can be found.
+func Parse(text string) *Amount {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	code := parseCurrency(text)
+	matches := numberRe.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		if freeRe.MatchString(text) {
+			return &Amount{Currency: code}
+		}
+		return nil
+	}
+
+	first, ok := parseNumber(text, matches[0])
+	if !ok {
+		return nil
+	}
+
+	amount := &Amount{Min: first, Max: first, Currency: code, PerPerson: personRe.MatchString(text)}
+	if len(matches) > 1 && timesRe.MatchString(text[matches[0][1]:matches[1][0]]) {
+		// "2 x IDR 50,000" is a count times a price.
+		if second, ok := parseNumber(text, matches[1]); ok {
+			amount.Min = first * second
+			amount.Max = amount.Min
+		}
+	} else if len(matches) > 1 && rangeRe.MatchString(text[matches[0][1]:matches[1][0]]) {
+		if second, ok := parseNumber(text, matches[1]); ok {
+			// "20-30k" applies the suffix to both ends.
+			if matches[0][4] < 0 && matches[1][4] >= 0 {
+				first *= multipliers[strings.ToLower(text[matches[1][4]:matches[1][5]])]
+			}
+			amount.Min = math.Min(first, second)
+			amount.Max = math.Max(first, second)
+		}
+	}
+
+	return amount
+}
+
+func parseCurrency(text string) string {
+	for _, code := range codeRe.FindAllString(text, -1) {
+		if _, err := currency.ParseISO(code); err == nil {
+			return code
+		}
+	}
+
+	for _, symbol := range symbols {
+		if symbol.pattern.MatchString(text) {
+			return symbol.code
+		}
+	}
+
+	return ""
+}
+
+// parseNumber interprets thousands separators the way travel prices are
+// usually written: "150,000" and "150.000" are both a hundred and fifty
+// thousand, while "12.50" and "12,5" are decimals.
+func parseNumber(text string, match []int) (float64, bool) {
+	digits := strings.TrimRight(text[match[2]:match[3]], ".,")
+
+	commas := strings.Count(digits, ",")
+	dots := strings.Count(digits, ".")
+	switch {
+	case commas > 0 && dots > 0:
+		if strings.LastIndex(digits, ",") > strings.LastIndex(digits, ".") {
+			digits = strings.ReplaceAll(digits, ".", "")
+			digits = strings.Replace(digits, ",", ".", 1)
+		} else {
+			digits = strings.ReplaceAll(digits, ",", "")
+		}
+	case commas+dots > 1:
+		digits = strings.NewReplacer(",", "", ".", "").Replace(digits)
+	case commas+dots == 1:
+		separator := strings.IndexAny(digits, ",.")
+		if len(digits)-separator-1 == 3 {
+			digits = digits[:separator] + digits[separator+1:]
+		} else {
+			digits = strings.Replace(digits, ",", ".", 1)
+		}
+	}
+
+	value, err := strconv.ParseFloat(digits, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	if match[4] >= 0 {
+		value *= multipliers[strings.ToLower(text[match[4]:match[5]])]
+	}
+
+	return value, true
+}
+
+// Extract parses every cost field of an itinerary.
+func Extract(trip map[string]interface{}) []Item {
+	items := make([]Item, 0)
+	add := func(path string, category string, value interface{}) {
+		raw, ok := value.(string)
+		if !ok {
+			return
+		}
+
+		items = append(items, Item{Path: path, Category: category, Raw: raw, Amount: Parse(raw)})
+	}
+
+	days, _ := trip["days"].([]interface{})
+	for i, day := range days {
+		d, _ := day.(map[string]interface{})
+		prefix := "days[" + strconv.Itoa(i) + "]"
+
+		if accommodation, ok := d["accommodation"].(map[string]interface{}); ok {
+			add(prefix+".accommodation.cost", CategoryLodging, accommodation["cost"])
+		}
+
+		meals, _ := d["meals"].(map[string]interface{})
+		for _, meal := range []string{"breakfast", "lunch", "dinner"} {
+			if m, ok := meals[meal].(map[string]interface{}); ok {
+				add(prefix+".meals."+meal+".cost", CategoryFood, m["cost"])
+			}
+		}
+
+		activities, _ := d["activities"].([]interface{})
+		for j, activity := range activities {
+			if a, ok := activity.(map[string]interface{}); ok {
+				add(prefix+".activities["+strconv.Itoa(j)+"].cost", CategoryActivities, a["cost"])
+			}
+		}
+
+		if transportation, ok := d["transportation"].(map[string]interface{}); ok {
+			add(prefix+".transportation.cost", CategoryTransport, transportation["cost"])
+		}
+	}
+
+	return items
+}
+
+// Summarize totals items per category in the budget's currency, or in the
+// currency most of the items use when there is no budget. Costs that do
+// not name a currency are taken to be in that most common one.
+//
+// Every cost is taken to be for the whole party of travelers, which is what
+// the trip prompt asks for. Costs the model still priced per person are
+// multiplied by travelers.
+func Summarize(items []Item, stated string, budget *Budget, rates Rates, travelers int) *Breakdown {
+	common := commonCurrency(items)
+	target := common
+	if budget != nil && budget.Currency != "" {
+		target = budget.Currency
+	}
+
+	breakdown := &Breakdown{
+		Currency: target,
+		Categories: map[string]Range{
+			CategoryLodging:    {},
+			CategoryFood:       {},
+			CategoryActivities: {},
+			CategoryTransport:  {},
+		},
+		Stated:      ForParty(Parse(stated), travelers),
+		Budget:      budget,
+		Unparsed:    make([]string, 0),
+		Unconverted: make([]string, 0),
+	}
+
+	for _, item := range items {
+		amount := ForParty(item.Amount, travelers)
+		if amount == nil {
+			breakdown.Unparsed = append(breakdown.Unparsed, item.Path)
+			continue
+		}
+
+		from := amount.Currency
+		if from == "" {
+			from = common
+		}
+
+		low, lok := rates.Convert(amount.Min, from, target)
+		high, hok := rates.Convert(amount.Max, from, target)
+		if !lok || !hok {
+			breakdown.Unconverted = append(breakdown.Unconverted, item.Path)
+			continue
+		}
+
+		total := breakdown.Categories[item.Category]
+		total.Min += low
+		total.Max += high
+		breakdown.Categories[item.Category] = total
+
+		breakdown.Total.Min += low
+		breakdown.Total.Max += high
+	}
+
+	for category, total := range breakdown.Categories {
+		breakdown.Categories[category] = Range{Min: round(total.Min), Max: round(total.Max)}
+	}
+	breakdown.Total = Range{Min: round(breakdown.Total.Min), Max: round(breakdown.Total.Max)}
+
+	if budget != nil && budget.Amount > 0 {
+		switch {
+		case breakdown.Total.Min > budget.Amount:
+			breakdown.BudgetStatus = BudgetOver
+			breakdown.Overrun = round(breakdown.Total.Min - budget.Amount)
+		case breakdown.Total.Max > budget.Amount:
+			breakdown.BudgetStatus = BudgetAtRisk
+			breakdown.Overrun = round(breakdown.Total.Max - budget.Amount)
+		default:
+			breakdown.BudgetStatus = BudgetWithin
+		}
+	}
+
+	return breakdown
+}
+
+// ForParty scales an amount priced per person to the whole party, the
+// convention every cost total follows.
+func ForParty(amount *Amount, travelers int) *Amount {
+	if amount == nil || !amount.PerPerson || travelers < 2 {
+		return amount
+	}
+
+	return &Amount{
+		Min:      amount.Min * float64(travelers),
+		Max:      amount.Max * float64(travelers),
+		Currency: amount.Currency,
+	}
+}
+
+func commonCurrency(items []Item) string {
+	counts := make(map[string]int)
+	for _, item := range items {
+		if item.Amount != nil && item.Amount.Currency != "" {
+			counts[item.Amount.Currency]++
+		}
+	}
+
+	codes := make([]string, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if counts[codes[i]] != counts[codes[j]] {
+			return counts[codes[i]] > counts[codes[j]]
+		}
+		return codes[i] < codes[j]
+	})
+
+	if len(codes) == 0 {
+		return ""
+	}
+
+	return codes[0]
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,80 @@
+package cost
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want *Amount
+	}{
+		{"IDR 150,000", &Amount{Min: 150000, Max: 150000, Currency: "IDR"}},
+		{"$20-30", &Amount{Min: 20, Max: 30, Currency: "USD"}},
+		{"Rp 50rb", &Amount{Min: 50000, Max: 50000, Currency: "IDR"}},
+		{"Rp 1.500.000", &Amount{Min: 1500000, Max: 1500000, Currency: "IDR"}},
+		{"S$12.50", &Amount{Min: 12.5, Max: 12.5, Currency: "SGD"}},
+		{"2 x IDR 50,000", &Amount{Min: 100000, Max: 100000, Currency: "IDR"}},
+		{"IDR 20-30k", &Amount{Min: 20000, Max: 30000, Currency: "IDR"}},
+		{"15 euros", &Amount{Min: 15, Max: 15, Currency: "EUR"}},
+		{"Rp50.000", &Amount{Min: 50000, Max: 50000, Currency: "IDR"}},
+		{"European art pass 30", &Amount{Min: 30, Max: 30}},
+		{"Wonderland ticket 200", &Amount{Min: 200, Max: 200}},
+		{"12.50", &Amount{Min: 12.5, Max: 12.5}},
+		{"IDR 75,000 per person", &Amount{Min: 75000, Max: 75000, Currency: "IDR", PerPerson: true}},
+		{"$15 pp", &Amount{Min: 15, Max: 15, Currency: "USD", PerPerson: true}},
+		{"Rp 50rb/orang", &Amount{Min: 50000, Max: 50000, Currency: "IDR", PerPerson: true}},
+		{"$40 per room", &Amount{Min: 40, Max: 40, Currency: "USD"}},
+		{"Free", &Amount{}},
+		{"Entrance included", &Amount{}},
+		{"Varies", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := Parse(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatesConvert(t *testing.T) {
+	rates := Rates{"USD": 1, "IDR": 16000, "EUR": 0.9}
+
+	if got, ok := rates.Convert(32000, "IDR", "USD"); !ok || got != 2 {
+		t.Errorf("Convert(IDR->USD) = %v, %v", got, ok)
+	}
+
+	if _, ok := rates.Convert(1, "XYZ", "USD"); ok {
+		t.Error("Convert() with an unknown currency reported success")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	items := []Item{
+		{Path: "days[0].accommodation.cost", Category: CategoryLodging, Amount: Parse("IDR 600,000")},
+		{Path: "days[0].activities[0].cost", Category: CategoryActivities, Amount: Parse("IDR 100,000 per person")},
+		{Path: "days[0].meals.lunch.cost", Category: CategoryFood, Amount: Parse("Varies")},
+	}
+
+	got := Summarize(items, "IDR 1,000,000", &Budget{Amount: 900000, Currency: "IDR"}, Rates{"IDR": 16000}, 4)
+
+	if want := (Range{Min: 1000000, Max: 1000000}); got.Total != want {
+		t.Errorf("Total = %+v, want %+v with the activity priced for four travelers", got.Total, want)
+	}
+
+	if got.Categories[CategoryActivities].Min != 400000 {
+		t.Errorf("activities = %+v, want 400000", got.Categories[CategoryActivities])
+	}
+
+	if got.BudgetStatus != BudgetOver || got.Overrun != 100000 {
+		t.Errorf("budget status = %q, overrun %v, want over by 100000", got.BudgetStatus, got.Overrun)
+	}
+
+	if !reflect.DeepEqual(got.Unparsed, []string{"days[0].meals.lunch.cost"}) {
+		t.Errorf("Unparsed = %v", got.Unparsed)
+	}
+}
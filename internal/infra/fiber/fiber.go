@@ -26,7 +26,7 @@ func New(env *env.Env) *fiber.App {
 	app.Use(healthcheck.New())
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE",
+		AllowMethods: "GET,POST,PUT,PATCH,DELETE",
 		AllowHeaders: "Content-Type,Authorization",
 	}))
 
@@ -0,0 +1,102 @@
+package guard
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ReasonTooLong   = "too_long"
+	ReasonInjection = "injection"
+	ReasonOffTopic  = "off_topic"
+
+	maxTotalLength = 4000
+)
+
+type GuardItf interface {
+	Check(places []string, texts ...string) *Rejection
+}
+
+// Rejection explains why user input was not sent to the model.
+type Rejection struct {
+	Reason string `json:"reason"`
+	Detail string `json:"detail"`
+}
+
+type Guard struct{}
+
+func NewGuard() GuardItf {
+	return &Guard{}
+}
+
+var injectionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\b.{0,30}\b(previous|prior|above|earlier|all|any|the|your)\b.{0,30}\b(instructions?|prompts?|rules|directions|guidelines)\b`),
+	regexp.MustCompile(`(?i)\b(abaikan|lupakan)\b.{0,30}\b(instruksi|perintah|aturan)\b`),
+	regexp.MustCompile(`(?i)\b(reveal|show|print|repeat|output)\b.{0,30}\b(system|hidden|initial)\s+(prompt|instructions?|message)\b`),
+	regexp.MustCompile(`(?i)\byou\s+are\s+(now|no\s+longer)\b`),
+	regexp.MustCompile(`(?i)\b(pretend|act)\s+(to\s+be|as)\b.{0,40}\b(ai|assistant|model|gpt|gemini|developer|admin)\b`),
+	regexp.MustCompile(`(?i)\b(jailbreak|dan\s+mode|developer\s+mode)\b`),
+	regexp.MustCompile(`(?im)^\s*(system|assistant|developer)\s*:`),
+	regexp.MustCompile(`(?i)<\|?(im_start|im_end|system|endoftext)\|?>`),
+	regexp.MustCompile(`(?i)\b(new|updated)\s+instructions?\s*:`),
+}
+
+var (
+	travelTerms   = regexp.MustCompile(`(?i)\b(trip|travel|travell?ing|vacation|holiday|itinerary|tour|visit|day|days|night|nights|weekend|hotel|hostel|resort|stay|flight|beach|museum|temple|food|restaurant|cafe|eat|hike|hiking|city|island|mountain|budget|cheaper|cost|activity|activities|sightseeing|explore|people|family|kids|honeymoon|liburan|wisata|jalan|hari|malam|pantai|gunung|kuliner|penginapan|murah)\b`)
+	offTopicTerms = regexp.MustCompile(`(?i)\b(coding|program|python|javascript|golang|sql|compile|debug|homework|essay|equation|integral|derivative|solve|calculus|poem|lyrics|novel|cover\s+letter|resume|password|hack|malware|exploit|crypto|bitcoin|invest|diagnos\w*|prescription)\b`)
+)
+
+// Check screens the user-provided parts of a prompt: places are the
+// structured destinations and must-see spots, texts the free-form fields. It
+// enforces an overall length limit, blocks known prompt-injection phrasing
+// and rejects text that reads more like a non-travel request than a travel
+// one. Every named place counts as a travel signal. Requests that say
+// nothing either way are let through; structured fields already constrain
+// those.
+func (g *Guard) Check(places []string, texts ...string) *Rejection {
+	all := append(slices.Clone(places), texts...)
+	total := 0
+	for _, text := range all {
+		total += utf8.RuneCountInString(text)
+	}
+
+	if total > maxTotalLength {
+		return &Rejection{
+			Reason: ReasonTooLong,
+			Detail: "Request text is " + strconv.Itoa(total) + " characters, the limit is " + strconv.Itoa(maxTotalLength),
+		}
+	}
+
+	input := strings.Join(all, "\n")
+	for _, pattern := range injectionPatterns {
+		if match := pattern.FindString(input); match != "" {
+			return &Rejection{
+				Reason: ReasonInjection,
+				Detail: "Request contains instructions aimed at the assistant: \"" + strings.TrimSpace(match) + "\"",
+			}
+		}
+	}
+
+	// Places are counted rather than read for topic, so a must-see such as
+	// "Crypto.com Arena" is still a place.
+	joined := strings.Join(texts, "\n")
+	offTopic := len(offTopicTerms.FindAllString(joined, -1))
+	travel := len(travelTerms.FindAllString(joined, -1))
+	for _, place := range places {
+		if strings.TrimSpace(place) != "" {
+			travel++
+		}
+	}
+
+	if offTopic > travel {
+		return &Rejection{
+			Reason: ReasonOffTopic,
+			Detail: "Request does not look like a travel planning request",
+		}
+	}
+
+	return nil
+}
@@ -3,11 +3,13 @@ package helper
 import (
 	"apac/internal/domain/env"
 	res "apac/internal/infra/response"
+	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
+	"net/http"
 	"reflect"
 	"strconv"
-	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -51,6 +53,13 @@ func (h Helper) FormParser(ctx *fiber.Ctx, target interface{}) error {
 		if values, ok := form.Value[formKey]; ok && len(values) > 0 {
 			valueStr := values[0]
 
+			// Pointer fields tell a value that was sent empty apart from
+			// one that was not sent at all.
+			if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() != reflect.Struct {
+				field.Set(reflect.New(field.Type().Elem()))
+				field = field.Elem()
+			}
+
 			switch field.Kind() {
 			case reflect.String:
 				field.SetString(valueStr)
@@ -78,14 +87,33 @@ func (h Helper) FormParser(ctx *fiber.Ctx, target interface{}) error {
 	return nil
 }
 
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
 func (h Helper) ValidateImage(file *multipart.FileHeader) *res.Err {
-	contentType := file.Header.Get("Content-Type")
-	if !strings.HasPrefix(contentType, "image/") {
+	if file.Size > 10*1024*1024 {
+		return res.ErrEntityTooLarge("Photo size must be less than 10MB")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return res.ErrInternalServer("Error opening file")
+	}
+
+	defer src.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
 		return res.ErrUnprocessableEntity("Invalid file type")
 	}
 
-	if file.Size > 10*1024*1024 {
-		return res.ErrEntityTooLarge("Photo size must be less than 10MB")
+	if !allowedImageTypes[http.DetectContentType(head[:n])] {
+		return res.ErrUnprocessableEntity("Invalid file type")
 	}
 
 	return nil
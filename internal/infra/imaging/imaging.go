@@ -0,0 +1,145 @@
+package imaging
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+	"strconv"
+
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	ContentType = "image/jpeg"
+	Extension   = ".jpg"
+	Original    = "original"
+
+	maxOriginalSize = 1024
+	maxPixels       = 40_000_000
+	jpegQuality     = 85
+)
+
+var ThumbnailSizes = []int{64, 256, 512}
+
+type ImagingItf interface {
+	Process(src io.Reader) (*Processed, error)
+}
+
+type Processed struct {
+	Hash     string
+	Variants map[string][]byte
+}
+
+type Imaging struct{}
+
+func NewImaging() ImagingItf {
+	return &Imaging{}
+}
+
+// Process decodes the image from its bytes and re-encodes every variant as
+// JPEG. Re-encoding from decoded pixels drops EXIF/GPS and any other metadata
+// carried by the upload. The hash is taken over the re-encoded original so
+// identical pictures always map to the same storage keys.
+func (i *Imaging) Process(src io.Reader) (*Processed, error) {
+	raw, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Width == 0 || cfg.Height == 0 {
+		return nil, errors.New("image has no pixels")
+	}
+
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, errors.New("image dimensions are too large")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := encode(fit(img, maxOriginalSize))
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(original)
+	processed := &Processed{
+		Hash:     hex.EncodeToString(sum[:]),
+		Variants: map[string][]byte{Original: original},
+	}
+
+	for _, size := range ThumbnailSizes {
+		data, err := encode(thumbnail(img, size))
+		if err != nil {
+			return nil, err
+		}
+
+		processed.Variants[VariantName(size)] = data
+	}
+
+	return processed, nil
+}
+
+func VariantName(size int) string {
+	return strconv.Itoa(size)
+}
+
+func encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fit scales img down so its longest side is at most limit, keeping the aspect ratio.
+func fit(img image.Image, limit int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= limit && h <= limit {
+		return scale(img, bounds, w, h)
+	}
+
+	if w >= h {
+		h = h * limit / w
+		w = limit
+	} else {
+		w = w * limit / h
+		h = limit
+	}
+
+	return scale(img, bounds, max(w, 1), max(h, 1))
+}
+
+// thumbnail center-crops img to a square and scales it to size x size.
+func thumbnail(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	return scale(img, image.Rect(x0, y0, x0+side, y0+side), size, size)
+}
+
+func scale(img image.Image, src image.Rectangle, w, h int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, src, draw.Over, nil)
+	return dst
+}
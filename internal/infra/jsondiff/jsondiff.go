@@ -0,0 +1,94 @@
+package jsondiff
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+const (
+	OpAdded   = "added"
+	OpRemoved = "removed"
+	OpChanged = "changed"
+)
+
+// Change describes a single leaf that differs between two JSON documents.
+// Path uses dots for object keys and brackets for array indexes, e.g.
+// "days[1].activities[0].cost".
+type Change struct {
+	Path   string      `json:"path"`
+	Op     string      `json:"op"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// Diff compares two decoded JSON documents. Objects are compared key by key
+// and arrays index by index, so inserting an element in the middle of an
+// array reports every following element as changed.
+func Diff(before, after interface{}) []Change {
+	changes := make([]Change, 0)
+	walk("", before, after, &changes)
+	return changes
+}
+
+func walk(path string, before, after interface{}, changes *[]Change) {
+	switch b := before.(type) {
+	case map[string]interface{}:
+		a, ok := after.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(b)+len(a))
+		for key := range b {
+			keys = append(keys, key)
+		}
+		for key := range a {
+			if _, ok := b[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+
+			bv, inBefore := b[key]
+			av, inAfter := a[key]
+			switch {
+			case !inAfter:
+				*changes = append(*changes, Change{Path: child, Op: OpRemoved, Before: bv})
+			case !inBefore:
+				*changes = append(*changes, Change{Path: child, Op: OpAdded, After: av})
+			default:
+				walk(child, bv, av, changes)
+			}
+		}
+		return
+	case []interface{}:
+		a, ok := after.([]interface{})
+		if !ok {
+			break
+		}
+
+		for i := 0; i < max(len(b), len(a)); i++ {
+			child := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= len(a):
+				*changes = append(*changes, Change{Path: child, Op: OpRemoved, Before: b[i]})
+			case i >= len(b):
+				*changes = append(*changes, Change{Path: child, Op: OpAdded, After: a[i]})
+			default:
+				walk(child, b[i], a[i], changes)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, Change{Path: path, Op: OpChanged, Before: before, After: after})
+	}
+}
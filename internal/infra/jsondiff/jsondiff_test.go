@@ -0,0 +1,73 @@
+package jsondiff
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decode(t *testing.T, text string) interface{} {
+	t.Helper()
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		t.Fatalf("invalid JSON %q: %v", text, err)
+	}
+
+	return value
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []Change
+	}{
+		{
+			name:   "equal",
+			before: `{"title":"Bali","days":[{"day":1}]}`,
+			after:  `{"title":"Bali","days":[{"day":1}]}`,
+			want:   []Change{},
+		},
+		{
+			name:   "nested change",
+			before: `{"days":[{"activities":[{"cost":"IDR 10,000"}]},{"activities":[{"cost":"Free"}]}]}`,
+			after:  `{"days":[{"activities":[{"cost":"IDR 10,000"}]},{"activities":[{"cost":"IDR 5,000"}]}]}`,
+			want:   []Change{{Path: "days[1].activities[0].cost", Op: OpChanged, Before: "Free", After: "IDR 5,000"}},
+		},
+		{
+			name:   "keys added and removed in sorted order",
+			before: `{"b":1,"c":2}`,
+			after:  `{"a":3,"c":2}`,
+			want: []Change{
+				{Path: "a", Op: OpAdded, After: float64(3)},
+				{Path: "b", Op: OpRemoved, Before: float64(1)},
+			},
+		},
+		{
+			name:   "array grows and shrinks by index",
+			before: `{"tags":["x","y","z"]}`,
+			after:  `{"tags":["y","z"]}`,
+			want: []Change{
+				{Path: "tags[0]", Op: OpChanged, Before: "x", After: "y"},
+				{Path: "tags[1]", Op: OpChanged, Before: "y", After: "z"},
+				{Path: "tags[2]", Op: OpRemoved, Before: "z"},
+			},
+		},
+		{
+			name:   "type change",
+			before: `{"cost":{"min":1}}`,
+			after:  `{"cost":"1 USD"}`,
+			want:   []Change{{Path: "cost", Op: OpChanged, Before: map[string]interface{}{"min": float64(1)}, After: "1 USD"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(decode(t, tt.before), decode(t, tt.after)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
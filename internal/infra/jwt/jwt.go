@@ -11,9 +11,9 @@ import (
 )
 
 type JWTItf interface {
-	GenerateAccessToken(userId uuid.UUID, name string, email string) (string, error)
+	GenerateAccessToken(userId uuid.UUID, name string, email string, role string) (string, error)
 	GenerateRefreshToken(userId uuid.UUID, rememberMe bool) (string, error)
-	VerifyAccessToken(token string) (uuid.UUID, string, string, error)
+	VerifyAccessToken(token string) (*AccessClaims, error)
 	VerifyRefreshToken(token string) (uuid.UUID, error)
 }
 
@@ -33,6 +33,7 @@ type AccessClaims struct {
 	UserID uuid.UUID `json:"user_id"`
 	Name   string    `json:"name"`
 	Email  string    `json:"email"`
+	Role   string    `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -42,11 +43,12 @@ type RefreshClaims struct {
 	jwt.RegisteredClaims
 }
 
-func (j *JWT) GenerateAccessToken(userId uuid.UUID, name string, email string) (string, error) {
+func (j *JWT) GenerateAccessToken(userId uuid.UUID, name string, email string, role string) (string, error) {
 	claims := AccessClaims{
 		UserID: userId,
 		Name:   name,
 		Email:  email,
+		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -80,21 +82,21 @@ func (j *JWT) GenerateRefreshToken(userId uuid.UUID, rememberMe bool) (string, e
 	return token.SignedString([]byte(j.refreshSecret))
 }
 
-func (j *JWT) VerifyAccessToken(tokenString string) (uuid.UUID, string, string, error) {
+func (j *JWT) VerifyAccessToken(tokenString string) (*AccessClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &AccessClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(j.accessSecret), nil
 	})
 
 	if err != nil || !token.Valid {
-		return uuid.Nil, "", "", errors.New("Invalid access token")
+		return nil, errors.New("Invalid access token")
 	}
 
 	claims, ok := token.Claims.(*AccessClaims)
 	if !ok {
-		return uuid.Nil, "", "", errors.New("Couldn't parse access token claims")
+		return nil, errors.New("Couldn't parse access token claims")
 	}
 
-	return claims.UserID, claims.Name, claims.Email, nil
+	return claims, nil
 }
 
 func (j *JWT) VerifyRefreshToken(tokenString string) (uuid.UUID, error) {
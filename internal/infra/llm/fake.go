@@ -0,0 +1,359 @@
+package llm
+
+import (
+	"apac/internal/domain/env"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	ProviderFake = "fake"
+
+	FakeFailureNone        = ""
+	FakeFailureError       = "error"
+	FakeFailureRateLimit   = "rate_limit"
+	FakeFailureUnavailable = "unavailable"
+	FakeFailureTimeout     = "timeout"
+	FakeFailureInvalidJSON = "invalid_json"
+
+	defaultFakeFixture = "./resource/fixtures/trip.json"
+	fakeStreamChunk    = 256
+)
+
+// fakeStart is the first day of trips whose prompt names no dates. It is
+// fixed so the output does not depend on when the test runs.
+var fakeStart = time.Date(2030, time.January, 14, 0, 0, 0, 0, time.UTC)
+
+// Fake builds itineraries from a fixture instead of calling a model, so the
+// API and integration tests can run offline. The same prompt always yields
+// the same trip.
+type Fake struct {
+	fixture   fakeFixture
+	latency   time.Duration
+	failure   string
+	failEvery int
+	calls     atomic.Int64
+}
+
+type fakeFixture struct {
+	Currency       string                       `json:"currency"`
+	Activities     []map[string]interface{}     `json:"activities"`
+	Meals          map[string]map[string]string `json:"meals"`
+	Accommodation  map[string]string            `json:"accommodation"`
+	Transportation map[string]string            `json:"transportation"`
+}
+
+func NewFake(env *env.Env) (TripGenerator, error) {
+	path := env.FakeLLMFixture
+	if path == "" {
+		path = defaultFakeFixture
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixture fakeFixture
+	if err := json.Unmarshal(content, &fixture); err != nil {
+		return nil, err
+	}
+
+	if len(fixture.Activities) == 0 {
+		return nil, errors.New("fake LLM fixture has no activities")
+	}
+
+	switch env.FakeLLMFailure {
+	case FakeFailureNone, FakeFailureError, FakeFailureRateLimit, FakeFailureUnavailable, FakeFailureTimeout, FakeFailureInvalidJSON:
+	default:
+		return nil, fmt.Errorf("unknown fake LLM failure mode %q", env.FakeLLMFailure)
+	}
+
+	return &Fake{
+		fixture:   fixture,
+		latency:   env.FakeLLMLatency,
+		failure:   env.FakeLLMFailure,
+		failEvery: env.FakeLLMFailEvery,
+	}, nil
+}
+
+func (f *Fake) Generate(ctx context.Context, prompt string) (map[string]interface{}, Usage, error) {
+	text, err := f.respond(ctx, prompt)
+	usage := fakeUsage(prompt, text)
+	if err != nil {
+		return nil, usage, err
+	}
+
+	response, err := decode(text)
+	return response, usage, err
+}
+
+func (f *Fake) GenerateStream(ctx context.Context, prompt string, onChunk func(string) error) (map[string]interface{}, Usage, error) {
+	text, err := f.respond(ctx, prompt)
+	usage := fakeUsage(prompt, text)
+	if err != nil {
+		return nil, usage, err
+	}
+
+	for start := 0; start < len(text); start += fakeStreamChunk {
+		end := min(start+fakeStreamChunk, len(text))
+		if err := onChunk(text[start:end]); err != nil {
+			return nil, usage, err
+		}
+	}
+
+	response, err := decode(text)
+	return response, usage, err
+}
+
+// fakeUsage approximates token counts at four bytes per token.
+func fakeUsage(prompt string, text string) Usage {
+	return Usage{
+		Provider:       ProviderFake,
+		Model:          ProviderFake,
+		PromptTokens:   (len(prompt) + 3) / 4,
+		ResponseTokens: (len(text) + 3) / 4,
+	}
+}
+
+// respond waits for the configured latency, applies the failure mode and
+// renders the itinerary as the raw text a model would have returned.
+func (f *Fake) respond(ctx context.Context, prompt string) (string, error) {
+	if f.latency > 0 {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(f.latency):
+		}
+	}
+
+	call := f.calls.Add(1)
+	if f.failure != FakeFailureNone && (f.failEvery <= 1 || call%int64(f.failEvery) == 0) {
+		switch f.failure {
+		case FakeFailureError:
+			return "", errors.New("fake: injected failure")
+		case FakeFailureRateLimit:
+			return "", &APIError{Provider: ProviderFake, StatusCode: http.StatusTooManyRequests, Message: "injected rate limit"}
+		case FakeFailureUnavailable:
+			return "", &APIError{Provider: ProviderFake, StatusCode: http.StatusServiceUnavailable, Message: "injected outage"}
+		case FakeFailureTimeout:
+			<-ctx.Done()
+			return "", ctx.Err()
+		case FakeFailureInvalidJSON:
+			return `{"title": "truncated`, nil
+		}
+	}
+
+	trip := f.build(parseFakePrompt(prompt))
+	if current := fakeCurrentRe.FindStringSubmatch(prompt); current != nil {
+		trip = f.edit(current[1], prompt)
+	}
+
+	content, err := json.Marshal(trip)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+type fakeRequest struct {
+	destination string
+	start       time.Time
+	days        int
+	travelers   int
+}
+
+var (
+	fakeDestinationRe = regexp.MustCompile(`(?:\b(?:to|in|at|visit|visiting|ke|di)\s+)(\p{Lu}[\p{L}'-]*(?:\s+\p{Lu}[\p{L}'-]*)*)`)
+	fakeDateRe        = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`)
+	fakeDaysRe        = regexp.MustCompile(`(?i)\b(\d{1,2})[\s-]*(?:days?|hari)\b`)
+	fakeTravelersRe   = regexp.MustCompile(`(?i)\b(\d{1,2})\s*(?:people|persons?|travell?ers?|adults?|pax|orang)\b`)
+
+	// Lines written by the structured prompt builder take precedence over
+	// free text.
+	fakeDestinationsLineRe = regexp.MustCompile(`(?m)^DESTINATIONS:\s*([^,\n]+)`)
+	fakeTravelersLineRe    = regexp.MustCompile(`(?m)^TRAVELERS:\s*(\d{1,2})`)
+
+	fakeCurrentRe     = regexp.MustCompile(`(?m)^CURRENT ITINERARY:\n(.+)$`)
+	fakeInstructionRe = regexp.MustCompile(`(?m)^CHANGE REQUEST:\s*(.+)$`)
+	fakeTranslateRe   = regexp.MustCompile(`(?m)^TRANSLATE INTO:.*?\(?([A-Za-z]{2,3}(?:-[A-Za-z0-9]+)*)\)?$`)
+	fakeTargetRe      = regexp.MustCompile(`(?m)^REPLACE ONLY: days\[(\d+)\](?:\.activities\[(\d+)\]|\.meals\.(\w+))?$`)
+)
+
+// fakeRetitle marks the fragment matched by fakeTargetRe as an alternative.
+func fakeRetitle(trip map[string]interface{}, m []string) {
+	days, _ := trip["days"].([]interface{})
+	index, _ := strconv.Atoi(m[1])
+	if index >= len(days) {
+		return
+	}
+
+	target, _ := days[index].(map[string]interface{})
+	switch {
+	case m[2] != "":
+		activities, _ := target["activities"].([]interface{})
+		activity, _ := strconv.Atoi(m[2])
+		if activity >= len(activities) {
+			return
+		}
+		target, _ = activities[activity].(map[string]interface{})
+	case m[3] != "":
+		meals, _ := target["meals"].(map[string]interface{})
+		target, _ = meals[m[3]].(map[string]interface{})
+	}
+
+	if title, ok := target["title"].(string); ok {
+		target["title"] = title + " (alternative)"
+	}
+}
+
+// edit answers a refinement prompt by echoing the current itinerary with the
+// instruction recorded in its summary, so callers see a small, predictable
+// change. Translations prefix the title and summary with the language tag.
+func (f *Fake) edit(current string, prompt string) map[string]interface{} {
+	trip, err := decode(current)
+	if err != nil {
+		return f.build(parseFakePrompt(prompt))
+	}
+
+	if m := fakeTargetRe.FindStringSubmatch(prompt); m != nil {
+		fakeRetitle(trip, m)
+		return trip
+	}
+
+	if m := fakeTranslateRe.FindStringSubmatch(prompt); m != nil {
+		for _, field := range []string{"title", "summary"} {
+			if text, ok := trip[field].(string); ok {
+				trip[field] = "[" + m[1] + "] " + text
+			}
+		}
+		return trip
+	}
+
+	if m := fakeInstructionRe.FindStringSubmatch(prompt); m != nil {
+		trip["summary"] = "Revised: " + strings.TrimSpace(m[1])
+	}
+
+	return trip
+}
+
+func parseFakePrompt(prompt string) fakeRequest {
+	req := fakeRequest{
+		destination: "Bali",
+		start:       fakeStart,
+		days:        3,
+		travelers:   1,
+	}
+
+	if m := fakeDestinationsLineRe.FindStringSubmatch(prompt); m != nil {
+		req.destination = strings.TrimSpace(m[1])
+	} else if m := fakeDestinationRe.FindStringSubmatch(prompt); m != nil {
+		req.destination = m[1]
+	}
+
+	if m := fakeDaysRe.FindStringSubmatch(prompt); m != nil {
+		if days, err := strconv.Atoi(m[1]); err == nil && days > 0 {
+			req.days = days
+		}
+	}
+
+	dates := fakeDateRe.FindAllString(prompt, 2)
+	if len(dates) > 0 {
+		if start, err := time.Parse(time.DateOnly, dates[0]); err == nil {
+			req.start = start
+		}
+	}
+
+	if len(dates) > 1 {
+		if end, err := time.Parse(time.DateOnly, dates[1]); err == nil && !end.Before(req.start) {
+			req.days = int(end.Sub(req.start).Hours()/24) + 1
+		}
+	}
+
+	m := fakeTravelersLineRe.FindStringSubmatch(prompt)
+	if m == nil {
+		m = fakeTravelersRe.FindStringSubmatch(prompt)
+	}
+
+	if m != nil {
+		if travelers, err := strconv.Atoi(m[1]); err == nil && travelers > 0 {
+			req.travelers = travelers
+		}
+	}
+
+	req.days = min(req.days, 30)
+
+	return req
+}
+
+func (f *Fake) build(req fakeRequest) map[string]interface{} {
+	replacer := strings.NewReplacer(
+		"{destination}", req.destination,
+		"{accommodation}", strings.ReplaceAll(f.fixture.Accommodation["name"], "{destination}", req.destination),
+		"{accommodationAddress}", strings.ReplaceAll(f.fixture.Accommodation["address"], "{destination}", req.destination),
+	)
+
+	fill := func(values map[string]string) map[string]interface{} {
+		filled := make(map[string]interface{}, len(values))
+		for key, value := range values {
+			filled[key] = replacer.Replace(value)
+		}
+		return filled
+	}
+
+	days := make([]interface{}, 0, req.days)
+	for i := 0; i < req.days; i++ {
+		activities := make([]interface{}, 0, 2)
+		for j := 0; j < 2; j++ {
+			template := f.fixture.Activities[(i*2+j)%len(f.fixture.Activities)]
+			activity := make(map[string]interface{}, len(template))
+			for key, value := range template {
+				if text, ok := value.(string); ok {
+					value = replacer.Replace(text)
+				}
+				activity[key] = value
+			}
+			activities = append(activities, activity)
+		}
+
+		meals := make(map[string]interface{}, len(f.fixture.Meals))
+		for name, meal := range f.fixture.Meals {
+			meals[name] = fill(meal)
+		}
+
+		days = append(days, map[string]interface{}{
+			"day":            i + 1,
+			"date":           req.start.AddDate(0, 0, i).Format(time.RFC3339),
+			"title":          fmt.Sprintf("Day %d in %s", i+1, req.destination),
+			"description":    fmt.Sprintf("Exploring %s, day %d of %d.", req.destination, i+1, req.days),
+			"activities":     activities,
+			"accommodation":  fill(f.fixture.Accommodation),
+			"meals":          meals,
+			"transportation": fill(f.fixture.Transportation),
+			"notes":          "Generated by the offline fake provider.",
+		})
+	}
+
+	return map[string]interface{}{
+		"title":       fmt.Sprintf("%d Days in %s", req.days, req.destination),
+		"destination": req.destination,
+		"startDate":   req.start.Format(time.RFC3339),
+		"endDate":     req.start.AddDate(0, 0, req.days-1).Format(time.RFC3339),
+		"duration":    req.days,
+		"travelers":   req.travelers,
+		"budget":      fmt.Sprintf("%s %d", f.fixture.Currency, 1500000*req.days*req.travelers),
+		"summary":     fmt.Sprintf("A %d-day offline sample itinerary for %d traveler(s) in %s.", req.days, req.travelers, req.destination),
+		"days":        days,
+		"totalCost":   fmt.Sprintf("%s %d", f.fixture.Currency, 1500000*req.days*req.travelers),
+	}
+}
@@ -0,0 +1,207 @@
+package llm
+
+import (
+	"apac/internal/domain/env"
+	"apac/internal/infra/schema"
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests run in the package directory, so resources are reached from there.
+const (
+	testFixture = "../../../resource/fixtures/trip.json"
+	testSchema  = "../../../resource/schema.json"
+)
+
+func newTestFake(t *testing.T, config *env.Env) TripGenerator {
+	t.Helper()
+
+	config.FakeLLMFixture = testFixture
+	fake, err := NewFake(config)
+	if err != nil {
+		t.Fatalf("NewFake() error = %v", err)
+	}
+
+	return fake
+}
+
+func TestFakeIsDeterministic(t *testing.T) {
+	prompt := "Plan a 4 day trip to Kyoto for 2 people"
+
+	first, _, err := newTestFake(t, &env.Env{}).Generate(context.Background(), prompt)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	second, _, err := newTestFake(t, &env.Env{}).Generate(context.Background(), prompt)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Error("the same prompt produced different trips")
+	}
+}
+
+func TestFakeReadsPrompt(t *testing.T) {
+	tests := []struct {
+		name        string
+		prompt      string
+		destination string
+		duration    float64
+		travelers   float64
+		startDate   string
+	}{
+		{
+			name:        "defaults",
+			prompt:      "Plan something nice",
+			destination: "Bali",
+			duration:    3,
+			travelers:   1,
+			startDate:   "2030-01-14T00:00:00Z",
+		},
+		{
+			name:        "free text",
+			prompt:      "A 5 day trip to Lombok for 3 people",
+			destination: "Lombok",
+			duration:    5,
+			travelers:   3,
+			startDate:   "2030-01-14T00:00:00Z",
+		},
+		{
+			name:        "structured lines and dates",
+			prompt:      "DESTINATIONS: Tokyo, Osaka\nDATES: 2031-04-01 to 2031-04-02\nTRAVELERS: 4",
+			destination: "Tokyo",
+			duration:    2,
+			travelers:   4,
+			startDate:   "2031-04-01T00:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip, _, err := newTestFake(t, &env.Env{}).Generate(context.Background(), tt.prompt)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			if trip["destination"] != tt.destination || trip["duration"] != tt.duration || trip["travelers"] != tt.travelers || trip["startDate"] != tt.startDate {
+				t.Errorf("got destination=%v duration=%v travelers=%v startDate=%v", trip["destination"], trip["duration"], trip["travelers"], trip["startDate"])
+			}
+		})
+	}
+}
+
+func TestFakeMatchesSchema(t *testing.T) {
+	validator, err := schema.NewSchema(testSchema)
+	if err != nil {
+		t.Fatalf("NewSchema() error = %v", err)
+	}
+
+	trip, _, err := newTestFake(t, &env.Env{}).Generate(context.Background(), "7 days in Bali for 2 travelers")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if errs := validator.Validate(trip); len(errs) > 0 {
+		t.Errorf("fake trip does not match the schema: %s", strings.Join(errs, "; "))
+	}
+}
+
+func TestFakeStreamMatchesGenerate(t *testing.T) {
+	fake := newTestFake(t, &env.Env{})
+	prompt := "3 days in Bali"
+
+	var streamed strings.Builder
+	trip, _, err := fake.GenerateStream(context.Background(), prompt, func(chunk string) error {
+		streamed.WriteString(chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GenerateStream() error = %v", err)
+	}
+
+	decoded, err := decode(streamed.String())
+	if err != nil {
+		t.Fatalf("streamed chunks do not decode: %v", err)
+	}
+
+	generated, _, _ := fake.Generate(context.Background(), prompt)
+	if !reflect.DeepEqual(trip, decoded) || !reflect.DeepEqual(trip, generated) {
+		t.Error("streamed trip differs from the generated one")
+	}
+}
+
+func TestFakeEdits(t *testing.T) {
+	current := `{"title":"Bali","summary":"Beaches","days":[{"title":"Day 1","activities":[{"title":"Surf"}]}]}`
+
+	tests := []struct {
+		name   string
+		prompt string
+		check  func(trip map[string]interface{}) bool
+	}{
+		{
+			name:   "refine",
+			prompt: "CHANGE REQUEST: more food\nCURRENT ITINERARY:\n" + current,
+			check: func(trip map[string]interface{}) bool {
+				return trip["summary"] == "Revised: more food"
+			},
+		},
+		{
+			name:   "translate",
+			prompt: "TRANSLATE INTO: Indonesian (id)\nCURRENT ITINERARY:\n" + current,
+			check: func(trip map[string]interface{}) bool {
+				return trip["title"] == "[id] Bali" && trip["summary"] == "[id] Beaches"
+			},
+		},
+		{
+			name:   "regenerate activity",
+			prompt: "REPLACE ONLY: days[0].activities[0]\nCURRENT ITINERARY:\n" + current,
+			check: func(trip map[string]interface{}) bool {
+				day := trip["days"].([]interface{})[0].(map[string]interface{})
+				activity := day["activities"].([]interface{})[0].(map[string]interface{})
+				return activity["title"] == "Surf (alternative)" && day["title"] == "Day 1"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip, _, err := newTestFake(t, &env.Env{}).Generate(context.Background(), tt.prompt)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			if !tt.check(trip) {
+				t.Errorf("unexpected trip: %v", trip)
+			}
+		})
+	}
+}
+
+func TestFakeFailures(t *testing.T) {
+	fake := newTestFake(t, &env.Env{FakeLLMFailure: FakeFailureRateLimit, FakeLLMFailEvery: 2})
+
+	if _, _, err := fake.Generate(context.Background(), "3 days in Bali"); err != nil {
+		t.Fatalf("first call error = %v, want success", err)
+	}
+
+	_, _, err := fake.Generate(context.Background(), "3 days in Bali")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("second call error = %v, want a rate limit", err)
+	}
+
+	fake = newTestFake(t, &env.Env{FakeLLMFailure: FakeFailureInvalidJSON})
+	if _, _, err := fake.Generate(context.Background(), "3 days in Bali"); err == nil {
+		t.Error("invalid JSON failure mode returned no error")
+	}
+
+	if _, err := NewFake(&env.Env{FakeLLMFixture: testFixture, FakeLLMFailure: "bogus"}); err == nil {
+		t.Error("unknown failure mode was accepted")
+	}
+}
@@ -0,0 +1,111 @@
+package llm
+
+import (
+	"apac/internal/domain/env"
+	"context"
+	"os"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+type Gemini struct {
+	client *genai.Client
+	config *genai.GenerateContentConfig
+	model  string
+}
+
+func NewGemini(env *env.Env) (TripGenerator, error) {
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		APIKey:  env.GeminiAPIKey,
+		Backend: genai.BackendGeminiAPI,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responseSchema, err := GetResponseSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &genai.GenerateContentConfig{
+		ResponseMIMEType: "application/json",
+		ResponseSchema:   responseSchema,
+	}
+
+	return &Gemini{
+		client: client,
+		config: config,
+		model:  env.GeminiModel,
+	}, nil
+}
+
+func GetResponseSchema() (*genai.Schema, error) {
+	content, err := os.ReadFile(SchemaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema genai.Schema
+	if err := schema.UnmarshalJSON(content); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+func (g *Gemini) Generate(ctx context.Context, prompt string) (map[string]interface{}, Usage, error) {
+	usage := Usage{Provider: ProviderGemini, Model: g.model}
+	result, err := g.client.Models.GenerateContent(
+		ctx,
+		g.model,
+		genai.Text(prompt),
+		g.config,
+	)
+	if err != nil {
+		return nil, usage, err
+	}
+
+	g.usage(&usage, result)
+
+	response, err := decode(result.Text())
+	return response, usage, err
+}
+
+func (g *Gemini) usage(usage *Usage, result *genai.GenerateContentResponse) {
+	if result.UsageMetadata == nil {
+		return
+	}
+
+	usage.PromptTokens = int(result.UsageMetadata.PromptTokenCount)
+	usage.ResponseTokens = int(result.UsageMetadata.CandidatesTokenCount)
+}
+
+// GenerateStream generates the same itinerary as Generate but hands every
+// text chunk to onChunk as it arrives. Cancelling ctx aborts the upstream call.
+func (g *Gemini) GenerateStream(ctx context.Context, prompt string, onChunk func(string) error) (map[string]interface{}, Usage, error) {
+	usage := Usage{Provider: ProviderGemini, Model: g.model}
+	var text strings.Builder
+	for result, err := range g.client.Models.GenerateContentStream(ctx, g.model, genai.Text(prompt), g.config) {
+		if err != nil {
+			return nil, usage, err
+		}
+
+		// Every chunk carries the running totals, the last one wins.
+		g.usage(&usage, result)
+
+		chunk := result.Text()
+		if chunk == "" {
+			continue
+		}
+
+		text.WriteString(chunk)
+		if err := onChunk(chunk); err != nil {
+			return nil, usage, err
+		}
+	}
+
+	response, err := decode(text.String())
+	return response, usage, err
+}
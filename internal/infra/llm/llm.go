@@ -0,0 +1,161 @@
+package llm
+
+import (
+	"apac/internal/domain/env"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+	ProviderOllama = "ollama"
+
+	SchemaPath = "./resource/schema.json"
+)
+
+// TripGenerator turns a fully built prompt into an itinerary
+// that follows resource/schema.json, regardless of which model produces it.
+// The returned Usage is filled in whenever the provider reported it, even if
+// the call failed afterwards, because those tokens are billed regardless.
+type TripGenerator interface {
+	Generate(ctx context.Context, prompt string) (map[string]interface{}, Usage, error)
+	GenerateStream(ctx context.Context, prompt string, onChunk func(string) error) (map[string]interface{}, Usage, error)
+}
+
+// Usage is the token accounting of one model call.
+type Usage struct {
+	Provider       string
+	Model          string
+	PromptTokens   int
+	ResponseTokens int
+}
+
+// New returns the configured provider wrapped with timeouts, retries and a
+// circuit breaker.
+func New(env *env.Env) (TripGenerator, error) {
+	generator, err := newProvider(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResilient(env, generator), nil
+}
+
+func newProvider(env *env.Env) (TripGenerator, error) {
+	switch strings.ToLower(env.LLMProvider) {
+	case "", ProviderGemini:
+		return NewGemini(env)
+	case ProviderOpenAI:
+		return NewOpenAI(env)
+	case ProviderOllama:
+		return NewOllama(env)
+	case ProviderFake:
+		return NewFake(env)
+	default:
+		return nil, fmt.Errorf("unknown LLM provider %q", env.LLMProvider)
+	}
+}
+
+// Model names the configured model as "<provider>/<model>", the label trips
+// and their feedback are grouped by.
+func Model(env *env.Env) string {
+	provider := strings.ToLower(env.LLMProvider)
+	switch provider {
+	case "", ProviderGemini:
+		return ProviderGemini + "/" + env.GeminiModel
+	case ProviderOpenAI:
+		return provider + "/" + env.OpenAIModel
+	case ProviderOllama:
+		return provider + "/" + env.OllamaModel
+	default:
+		return provider
+	}
+}
+
+// LoadSchema reads the trip JSON schema shared by every provider.
+func LoadSchema() (map[string]interface{}, error) {
+	content, err := os.ReadFile(SchemaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(content, &schema); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+func decode(text string) (map[string]interface{}, error) {
+	var response map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		return nil, &DecodeError{Text: text, Err: err}
+	}
+
+	return response, nil
+}
+
+// DecodeError is returned when the model answered with something other than
+// a JSON object, for instance because the answer was cut off. Text is the raw
+// answer, which the model can be asked to repair.
+type DecodeError struct {
+	Text string
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return "response is not valid JSON: " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// APIError is returned when a provider answers with a non-2xx status.
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: status %d: %s", e.Provider, e.StatusCode, e.Message)
+}
+
+func postJSON(ctx context.Context, client *http.Client, provider string, url string, headers map[string]string, body interface{}) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, &APIError{Provider: provider, StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(message))}
+	}
+
+	return resp, nil
+}
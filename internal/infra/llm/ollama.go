@@ -0,0 +1,140 @@
+package llm
+
+import (
+	"apac/internal/domain/env"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Ollama talks to a local Ollama server. The trip schema is passed as the
+// "format" option so the model is constrained to schema-shaped JSON.
+type Ollama struct {
+	client  *http.Client
+	baseURL string
+	model   string
+	schema  map[string]interface{}
+}
+
+type ollamaResponse struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Done            bool   `json:"done"`
+	Error           string `json:"error"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
+
+func (r *ollamaResponse) usage(usage *Usage) {
+	if !r.Done {
+		return
+	}
+
+	usage.PromptTokens = r.PromptEvalCount
+	usage.ResponseTokens = r.EvalCount
+}
+
+func NewOllama(env *env.Env) (TripGenerator, error) {
+	if env.OllamaUrl == "" || env.OllamaModel == "" {
+		return nil, errors.New("OLLAMA_URL and OLLAMA_MODEL are required for the ollama provider")
+	}
+
+	schema, err := LoadSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ollama{
+		client:  &http.Client{},
+		baseURL: strings.TrimRight(env.OllamaUrl, "/"),
+		model:   env.OllamaModel,
+		schema:  schema,
+	}, nil
+}
+
+func (o *Ollama) request(prompt string, stream bool) map[string]interface{} {
+	return map[string]interface{}{
+		"model": o.model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+		"format": o.schema,
+		"stream": stream,
+	}
+}
+
+func (o *Ollama) Generate(ctx context.Context, prompt string) (map[string]interface{}, Usage, error) {
+	usage := Usage{Provider: ProviderOllama, Model: o.model}
+	resp, err := postJSON(ctx, o.client, ProviderOllama, o.baseURL+"/api/chat", nil, o.request(prompt, false))
+	if err != nil {
+		return nil, usage, err
+	}
+
+	defer resp.Body.Close()
+
+	var result ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, usage, err
+	}
+
+	if result.Error != "" {
+		return nil, usage, errors.New("ollama: " + result.Error)
+	}
+
+	result.usage(&usage)
+
+	response, err := decode(result.Message.Content)
+	return response, usage, err
+}
+
+func (o *Ollama) GenerateStream(ctx context.Context, prompt string, onChunk func(string) error) (map[string]interface{}, Usage, error) {
+	usage := Usage{Provider: ProviderOllama, Model: o.model}
+	resp, err := postJSON(ctx, o.client, ProviderOllama, o.baseURL+"/api/chat", nil, o.request(prompt, true))
+	if err != nil {
+		return nil, usage, err
+	}
+
+	defer resp.Body.Close()
+
+	var text strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var event ollamaResponse
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, usage, err
+		}
+
+		if event.Error != "" {
+			return nil, usage, errors.New("ollama: " + event.Error)
+		}
+
+		if chunk := event.Message.Content; chunk != "" {
+			text.WriteString(chunk)
+			if err := onChunk(chunk); err != nil {
+				return nil, usage, err
+			}
+		}
+
+		if event.Done {
+			event.usage(&usage)
+			break
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, usage, err
+	}
+
+	response, err := decode(text.String())
+	return response, usage, err
+}
@@ -0,0 +1,172 @@
+package llm
+
+import (
+	"apac/internal/domain/env"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// OpenAI talks to any endpoint implementing the OpenAI chat completions API,
+// asking for structured output that matches the trip schema.
+type OpenAI struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+	schema  map[string]interface{}
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+		Delta   openAIMessage `json:"delta"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+func (r *openAIResponse) usage(usage *Usage) {
+	if r.Usage == nil {
+		return
+	}
+
+	usage.PromptTokens = r.Usage.PromptTokens
+	usage.ResponseTokens = r.Usage.CompletionTokens
+}
+
+func NewOpenAI(env *env.Env) (TripGenerator, error) {
+	if env.OpenAIBaseUrl == "" || env.OpenAIModel == "" {
+		return nil, errors.New("OPENAI_BASE_URL and OPENAI_MODEL are required for the openai provider")
+	}
+
+	schema, err := LoadSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAI{
+		client:  &http.Client{},
+		baseURL: strings.TrimRight(env.OpenAIBaseUrl, "/"),
+		apiKey:  env.OpenAIAPIKey,
+		model:   env.OpenAIModel,
+		schema:  schema,
+	}, nil
+}
+
+func (o *OpenAI) request(prompt string, stream bool) map[string]interface{} {
+	request := map[string]interface{}{
+		"model": o.model,
+		"messages": []openAIMessage{
+			{Role: "system", Content: "You are a travel planner. Answer only with JSON matching the given schema."},
+			{Role: "user", Content: prompt},
+		},
+		"response_format": map[string]interface{}{
+			"type": "json_schema",
+			"json_schema": map[string]interface{}{
+				"name":   "trip",
+				"schema": o.schema,
+			},
+		},
+		"stream": stream,
+	}
+
+	if stream {
+		// Token counts only arrive in a final chunk when asked for.
+		request["stream_options"] = map[string]interface{}{"include_usage": true}
+	}
+
+	return request
+}
+
+func (o *OpenAI) headers() map[string]string {
+	headers := make(map[string]string)
+	if o.apiKey != "" {
+		headers["Authorization"] = "Bearer " + o.apiKey
+	}
+
+	return headers
+}
+
+func (o *OpenAI) Generate(ctx context.Context, prompt string) (map[string]interface{}, Usage, error) {
+	usage := Usage{Provider: ProviderOpenAI, Model: o.model}
+	resp, err := postJSON(ctx, o.client, ProviderOpenAI, o.baseURL+"/chat/completions", o.headers(), o.request(prompt, false))
+	if err != nil {
+		return nil, usage, err
+	}
+
+	defer resp.Body.Close()
+
+	var result openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, usage, err
+	}
+
+	result.usage(&usage)
+
+	if len(result.Choices) == 0 {
+		return nil, usage, errors.New("openai: response has no choices")
+	}
+
+	response, err := decode(result.Choices[0].Message.Content)
+	return response, usage, err
+}
+
+func (o *OpenAI) GenerateStream(ctx context.Context, prompt string, onChunk func(string) error) (map[string]interface{}, Usage, error) {
+	usage := Usage{Provider: ProviderOpenAI, Model: o.model}
+	resp, err := postJSON(ctx, o.client, ProviderOpenAI, o.baseURL+"/chat/completions", o.headers(), o.request(prompt, true))
+	if err != nil {
+		return nil, usage, err
+	}
+
+	defer resp.Body.Close()
+
+	var text strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var event openAIResponse
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return nil, usage, err
+		}
+
+		event.usage(&usage)
+
+		if len(event.Choices) == 0 || event.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		chunk := event.Choices[0].Delta.Content
+		text.WriteString(chunk)
+		if err := onChunk(chunk); err != nil {
+			return nil, usage, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, usage, err
+	}
+
+	response, err := decode(text.String())
+	return response, usage, err
+}
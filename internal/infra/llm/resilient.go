@@ -0,0 +1,220 @@
+package llm
+
+import (
+	"apac/internal/domain/env"
+	"context"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 8 * time.Second
+)
+
+// ErrCircuitOpen is returned without calling the provider while the circuit
+// breaker considers it down.
+var ErrCircuitOpen = errors.New("llm: circuit breaker is open")
+
+// Resilient wraps a TripGenerator with a deadline per call, retries with
+// jittered exponential backoff for rate limits and server errors, and a
+// circuit breaker that fails fast after repeated failures.
+type Resilient struct {
+	next       TripGenerator
+	timeout    time.Duration
+	maxRetries int
+	breaker    *breaker
+}
+
+func NewResilient(env *env.Env, next TripGenerator) TripGenerator {
+	return &Resilient{
+		next:       next,
+		timeout:    env.LLMTimeout,
+		maxRetries: env.LLMMaxRetries,
+		breaker:    &breaker{threshold: env.LLMBreakerThreshold, cooldown: env.LLMBreakerCooldown},
+	}
+}
+
+func (r *Resilient) Generate(ctx context.Context, prompt string) (map[string]interface{}, Usage, error) {
+	return r.call(ctx, func(ctx context.Context) (map[string]interface{}, Usage, bool, error) {
+		response, usage, err := r.next.Generate(ctx, prompt)
+		return response, usage, true, err
+	})
+}
+
+// GenerateStream only retries while nothing has been handed to onChunk yet;
+// once the client has seen part of an answer a retry would duplicate it.
+func (r *Resilient) GenerateStream(ctx context.Context, prompt string, onChunk func(string) error) (map[string]interface{}, Usage, error) {
+	return r.call(ctx, func(ctx context.Context) (map[string]interface{}, Usage, bool, error) {
+		started := false
+		response, usage, err := r.next.GenerateStream(ctx, prompt, func(chunk string) error {
+			started = true
+			return onChunk(chunk)
+		})
+		return response, usage, !started, err
+	})
+}
+
+func (r *Resilient) call(ctx context.Context, attempt func(ctx context.Context) (map[string]interface{}, Usage, bool, error)) (map[string]interface{}, Usage, error) {
+	var total Usage
+	for try := 0; ; try++ {
+		if !r.breaker.allow() {
+			return nil, total, ErrCircuitOpen
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		response, usage, retryable, err := attempt(callCtx)
+		cancel()
+
+		total = addUsage(total, usage)
+		if err == nil {
+			r.breaker.success()
+			return response, total, nil
+		}
+
+		// The caller giving up is not the provider's fault.
+		if ctx.Err() != nil {
+			r.breaker.release()
+			return nil, total, err
+		}
+
+		r.breaker.failure(err)
+
+		if !retryable || !Retryable(err) || try >= r.maxRetries {
+			return nil, total, err
+		}
+
+		delay := backoff(try)
+		log.Printf("LLM call failed, retrying in %s (attempt %d of %d): %v\n", delay, try+1, r.maxRetries, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, total, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// backoff returns a delay with full jitter, between zero and an exponentially
+// growing cap.
+func backoff(try int) time.Duration {
+	limit := min(retryBaseDelay<<try, retryMaxDelay)
+	return time.Duration(rand.Int64N(int64(limit))) + time.Millisecond
+}
+
+func addUsage(total Usage, usage Usage) Usage {
+	if usage.Provider != "" {
+		total.Provider = usage.Provider
+		total.Model = usage.Model
+	}
+
+	total.PromptTokens += usage.PromptTokens
+	total.ResponseTokens += usage.ResponseTokens
+	return total
+}
+
+// StatusCode extracts the HTTP status of a provider error, or 0 if there is
+// none.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	var genaiErr genai.APIError
+	if errors.As(err, &genaiErr) {
+		return genaiErr.Code
+	}
+
+	return 0
+}
+
+// Retryable reports whether err is worth another attempt: rate limits,
+// server errors and timeouts of a single call.
+func Retryable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	code := StatusCode(err)
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// unhealthy reports whether err says something about the provider: it could
+// not be reached, timed out, was rate limited or failed on its side. An
+// answer that is not JSON or a chunk the caller failed to handle does not.
+func unhealthy(err error) bool {
+	var netErr net.Error
+	return Retryable(err) || errors.As(err, &netErr)
+}
+
+// breaker opens after threshold consecutive failures and lets a single trial
+// call through once cooldown has passed. Only failures of the provider count;
+// other errors, such as a rejected prompt, leave the count as it is.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+
+	if time.Now().Before(b.openUntil) || b.trial {
+		return false
+	}
+
+	b.trial = true
+	return true
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.trial = false
+}
+
+func (b *breaker) failure(err error) {
+	if !unhealthy(err) {
+		b.release()
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.trial = false
+	if b.failures >= b.threshold {
+		if b.failures == b.threshold {
+			log.Printf("LLM circuit breaker opened after %d consecutive failures\n", b.failures)
+		}
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// release ends a trial call that said nothing about the provider's health,
+// such as one the caller cancelled, so the next call can probe again.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
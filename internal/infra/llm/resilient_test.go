@@ -0,0 +1,116 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// stubGenerator answers every call with err, or an empty trip when err is nil.
+type stubGenerator struct {
+	err error
+}
+
+func (g *stubGenerator) Generate(ctx context.Context, prompt string) (map[string]interface{}, Usage, error) {
+	if g.err != nil {
+		return nil, Usage{}, g.err
+	}
+
+	return map[string]interface{}{}, Usage{}, nil
+}
+
+func (g *stubGenerator) GenerateStream(ctx context.Context, prompt string, onChunk func(string) error) (map[string]interface{}, Usage, error) {
+	return g.Generate(ctx, prompt)
+}
+
+func TestBreakerProbe(t *testing.T) {
+	const cooldown = 10 * time.Millisecond
+
+	tests := []struct {
+		name  string
+		probe func(stub *stubGenerator) (context.Context, context.CancelFunc)
+	}{
+		{
+			name: "cancelled by the caller",
+			probe: func(stub *stubGenerator) (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				stub.err = context.Canceled
+				return ctx, cancel
+			},
+		},
+		{
+			name: "rejected as a client error",
+			probe: func(stub *stubGenerator) (context.Context, context.CancelFunc) {
+				stub.err = &APIError{StatusCode: http.StatusBadRequest}
+				return context.WithCancel(context.Background())
+			},
+		},
+		{
+			name: "answered with invalid JSON",
+			probe: func(stub *stubGenerator) (context.Context, context.CancelFunc) {
+				stub.err = &DecodeError{Text: "{", Err: errors.New("unexpected end of JSON input")}
+				return context.WithCancel(context.Background())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubGenerator{err: &APIError{StatusCode: http.StatusServiceUnavailable}}
+			resilient := &Resilient{
+				next:    stub,
+				timeout: time.Second,
+				breaker: &breaker{threshold: 1, cooldown: cooldown},
+			}
+
+			if _, _, err := resilient.Generate(context.Background(), "prompt"); err == nil {
+				t.Fatal("failing provider returned no error")
+			}
+
+			if _, _, err := resilient.Generate(context.Background(), "prompt"); !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("error = %v, want the circuit to be open", err)
+			}
+
+			time.Sleep(cooldown)
+
+			ctx, cancel := tt.probe(stub)
+			defer cancel()
+			if _, _, err := resilient.Generate(ctx, "prompt"); err == nil || errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("probe error = %v, want it to reach the provider", err)
+			}
+
+			stub.err = nil
+			if _, _, err := resilient.Generate(context.Background(), "prompt"); err != nil {
+				t.Errorf("call after the probe error = %v, want the provider to be tried again", err)
+			}
+		})
+	}
+}
+
+func TestUnhealthy(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"server error", &APIError{StatusCode: http.StatusBadGateway}, true},
+		{"rate limited", &APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{"deadline", context.DeadlineExceeded, true},
+		{"unreachable", &url.Error{Op: "Post", URL: "http://localhost", Err: errors.New("connection refused")}, true},
+		{"client error", &APIError{StatusCode: http.StatusBadRequest}, false},
+		{"invalid JSON", &DecodeError{Text: "{", Err: errors.New("unexpected end of JSON input")}, false},
+		{"chunk handler", errors.New("write: broken pipe"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unhealthy(tt.err); got != tt.want {
+				t.Errorf("unhealthy(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package packing
+
+import (
+	"apac/internal/infra/schema"
+	"strings"
+	"unicode"
+)
+
+const (
+	CategoryDocuments   = "documents"
+	CategoryClothing    = "clothing"
+	CategoryToiletries  = "toiletries"
+	CategoryHealth      = "health"
+	CategoryElectronics = "electronics"
+	CategoryGear        = "gear"
+	CategoryOther       = "other"
+
+	// maxOutfits caps clothing counts; longer trips are expected to do laundry.
+	maxOutfits = 7
+)
+
+// Categories lists the checklist sections in the order they are shown.
+var Categories = []string{CategoryDocuments, CategoryClothing, CategoryToiletries, CategoryHealth, CategoryElectronics, CategoryGear, CategoryOther}
+
+type PackingItf interface {
+	Build(trip map[string]interface{}) []Item
+}
+
+type Item struct {
+	Category string
+	Name     string
+	Quantity int
+}
+
+type Packing struct{}
+
+func NewPacking() PackingItf {
+	return &Packing{}
+}
+
+// tagRule adds items when any activity tag contains one of keywords as whole
+// words. Keywords list the inflections tags are written in.
+type tagRule struct {
+	keywords []string
+	items    []Item
+}
+
+var tagRules = []tagRule{
+	{
+		keywords: []string{"beach", "beaches", "swim", "swimming", "island", "islands", "pantai", "pool", "surf", "surfing"},
+		items: []Item{
+			{Category: CategoryClothing, Name: "Swimwear"},
+			{Category: CategoryClothing, Name: "Sandals or flip-flops"},
+			{Category: CategoryGear, Name: "Beach towel"},
+			{Category: CategoryToiletries, Name: "Reef-safe sunscreen"},
+			{Category: CategoryGear, Name: "Sunglasses"},
+		},
+	},
+	{
+		keywords: []string{"snorkel", "snorkeling", "snorkelling", "diving", "dive", "scuba"},
+		items: []Item{
+			{Category: CategoryGear, Name: "Snorkel mask"},
+			{Category: CategoryGear, Name: "Waterproof phone pouch"},
+		},
+	},
+	{
+		keywords: []string{"hike", "hiking", "trek", "trekking", "mountain", "mountains", "volcano", "nature", "outdoor", "outdoors", "waterfall", "waterfalls", "gunung"},
+		items: []Item{
+			{Category: CategoryClothing, Name: "Hiking shoes"},
+			{Category: CategoryGear, Name: "Daypack"},
+			{Category: CategoryGear, Name: "Reusable water bottle"},
+			{Category: CategoryHealth, Name: "Insect repellent"},
+			{Category: CategoryHealth, Name: "Blister plasters"},
+		},
+	},
+	{
+		keywords: []string{"temple", "temples", "mosque", "church", "religious", "shrine", "pura", "culture", "cultural"},
+		items: []Item{
+			{Category: CategoryClothing, Name: "Clothes covering shoulders and knees"},
+			{Category: CategoryClothing, Name: "Sarong or scarf"},
+		},
+	},
+	{
+		keywords: []string{"ski", "skiing", "snow", "winter", "glacier"},
+		items: []Item{
+			{Category: CategoryClothing, Name: "Thermal base layers"},
+			{Category: CategoryClothing, Name: "Insulated jacket"},
+			{Category: CategoryClothing, Name: "Gloves and beanie"},
+		},
+	},
+	{
+		keywords: []string{"nightlife", "bar", "bars", "club", "clubs", "fine dining", "party"},
+		items: []Item{
+			{Category: CategoryClothing, Name: "Evening outfit"},
+		},
+	},
+	{
+		keywords: []string{"photo", "photography", "sunrise", "sunset", "viewpoint", "scenic"},
+		items: []Item{
+			{Category: CategoryElectronics, Name: "Camera and spare memory card"},
+		},
+	},
+	{
+		keywords: []string{"shopping", "market", "markets"},
+		items: []Item{
+			{Category: CategoryGear, Name: "Foldable shopping bag"},
+		},
+	},
+	{
+		keywords: []string{"adventure", "rafting", "kayak", "kayaking", "boat", "cruise"},
+		items: []Item{
+			{Category: CategoryGear, Name: "Dry bag"},
+			{Category: CategoryHealth, Name: "Motion sickness tablets"},
+		},
+	},
+}
+
+// Build derives a checklist from the trip's destination, dates, activity
+// tags and traveler count. Items that depend on the group size are counted
+// per traveler; everything else is shared.
+func (p *Packing) Build(trip map[string]interface{}) []Item {
+	travelers := intValue(trip["travelers"])
+	if travelers < 1 {
+		travelers = 1
+	}
+
+	days := tripDays(trip)
+	outfits := min(days, maxOutfits)
+	destination, _ := trip["destination"].(string)
+
+	items := []Item{
+		{Category: CategoryDocuments, Name: "Passport or ID card", Quantity: travelers},
+		{Category: CategoryDocuments, Name: "Travel insurance details"},
+		{Category: CategoryDocuments, Name: "Booking confirmations"},
+		{Category: CategoryDocuments, Name: "Payment cards and some cash"},
+		{Category: CategoryClothing, Name: "Tops", Quantity: outfits * travelers},
+		{Category: CategoryClothing, Name: "Trousers, shorts or skirts", Quantity: (outfits + 1) / 2 * travelers},
+		{Category: CategoryClothing, Name: "Underwear", Quantity: (outfits + 1) * travelers},
+		{Category: CategoryClothing, Name: "Socks", Quantity: (outfits + 1) * travelers},
+		{Category: CategoryClothing, Name: "Sleepwear", Quantity: travelers},
+		{Category: CategoryClothing, Name: "Comfortable walking shoes", Quantity: travelers},
+		{Category: CategoryClothing, Name: "Light rain jacket", Quantity: travelers},
+		{Category: CategoryToiletries, Name: "Toothbrush", Quantity: travelers},
+		{Category: CategoryToiletries, Name: "Toothpaste"},
+		{Category: CategoryToiletries, Name: "Deodorant"},
+		{Category: CategoryToiletries, Name: "Shampoo and soap"},
+		{Category: CategoryHealth, Name: "Personal medication"},
+		{Category: CategoryHealth, Name: "Basic first aid kit"},
+		{Category: CategoryHealth, Name: "Hand sanitizer"},
+		{Category: CategoryElectronics, Name: "Phone and charger", Quantity: travelers},
+		{Category: CategoryElectronics, Name: "Power bank"},
+		{Category: CategoryElectronics, Name: "Travel adapter"},
+	}
+
+	if destination != "" {
+		items = append(items,
+			Item{Category: CategoryDocuments, Name: "Visa or entry permit for " + destination + " if required"},
+			Item{Category: CategoryDocuments, Name: "Offline map of " + destination},
+		)
+	}
+
+	if days > maxOutfits {
+		items = append(items,
+			Item{Category: CategoryOther, Name: "Laundry bag"},
+			Item{Category: CategoryToiletries, Name: "Travel laundry detergent"},
+		)
+	}
+
+	tags := activityTags(trip)
+	for _, rule := range tagRules {
+		if !matches(tags, rule.keywords) {
+			continue
+		}
+
+		for _, item := range rule.items {
+			// Everyone needs their own clothes.
+			if item.Category == CategoryClothing {
+				item.Quantity = travelers
+			}
+			items = append(items, item)
+		}
+	}
+
+	return dedupe(items)
+}
+
+// tripDays prefers the duration field and falls back to the date range or
+// the number of planned days.
+func tripDays(trip map[string]interface{}) int {
+	if duration := intValue(trip["duration"]); duration > 0 {
+		return duration
+	}
+
+	startDate, _ := trip["startDate"].(string)
+	endDate, _ := trip["endDate"].(string)
+	start, serr := schema.ParseDate(startDate)
+	end, eerr := schema.ParseDate(endDate)
+	if serr == nil && eerr == nil && !end.Before(start) {
+		return int(end.Sub(start).Hours()/24) + 1
+	}
+
+	days, _ := trip["days"].([]interface{})
+	return max(len(days), 1)
+}
+
+// activityTags returns every activity tag as its lowercase words, separated
+// and surrounded by single spaces so keywords can be found as whole words.
+func activityTags(trip map[string]interface{}) []string {
+	tags := make([]string, 0)
+	days, _ := trip["days"].([]interface{})
+	for _, day := range days {
+		d, _ := day.(map[string]interface{})
+		activities, _ := d["activities"].([]interface{})
+		for _, activity := range activities {
+			a, _ := activity.(map[string]interface{})
+			values, _ := a["tags"].([]interface{})
+			for _, value := range values {
+				if tag, ok := value.(string); ok {
+					words := strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool {
+						return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+					})
+					tags = append(tags, " "+strings.Join(words, " ")+" ")
+				}
+			}
+		}
+	}
+
+	return tags
+}
+
+// matches reports whether a tag contains a keyword as whole words, so "bar"
+// matches "rooftop bar" but not "barong".
+func matches(tags []string, keywords []string) bool {
+	for _, tag := range tags {
+		for _, keyword := range keywords {
+			if strings.Contains(tag, " "+keyword+" ") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// dedupe drops repeated items, which happens when several rules apply, and
+// gives shared items a quantity of one.
+func dedupe(items []Item) []Item {
+	seen := make(map[string]bool, len(items))
+	unique := make([]Item, 0, len(items))
+	for _, item := range items {
+		key := item.Category + "\x00" + item.Name
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if item.Quantity < 1 {
+			item.Quantity = 1
+		}
+		unique = append(unique, item)
+	}
+
+	return unique
+}
+
+func intValue(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
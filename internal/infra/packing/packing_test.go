@@ -0,0 +1,96 @@
+package packing
+
+import "testing"
+
+// tagged returns a one-day itinerary whose only activity carries tags.
+func tagged(tags ...string) []interface{} {
+	values := make([]interface{}, len(tags))
+	for i, tag := range tags {
+		values[i] = tag
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"activities": []interface{}{
+				map[string]interface{}{"tags": values},
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name   string
+		trip   map[string]interface{}
+		want   map[string]int
+		absent []string
+	}{
+		{
+			name:   "defaults to one traveler and one day",
+			trip:   map[string]interface{}{},
+			want:   map[string]int{"Passport or ID card": 1, "Tops": 1, "Underwear": 2, "Toothpaste": 1},
+			absent: []string{"Laundry bag"},
+		},
+		{
+			name: "scales personal items by travelers and clothing by days",
+			trip: map[string]interface{}{"travelers": float64(2), "duration": float64(3), "destination": "Bali"},
+			want: map[string]int{
+				"Passport or ID card":        2,
+				"Tops":                       6,
+				"Trousers, shorts or skirts": 4,
+				"Underwear":                  8,
+				"Toothpaste":                 1,
+				"Offline map of Bali":        1,
+			},
+		},
+		{
+			name: "caps clothing on long trips and adds laundry",
+			trip: map[string]interface{}{"duration": float64(10)},
+			want: map[string]int{"Tops": 7, "Underwear": 8, "Laundry bag": 1},
+		},
+		{
+			name: "counts days from the date range without a duration",
+			trip: map[string]interface{}{"startDate": "2030-01-14T09:00:00Z", "endDate": "2030-01-16"},
+			want: map[string]int{"Tops": 3},
+		},
+		{
+			name: "adds items for whole-word tags",
+			trip: map[string]interface{}{"travelers": float64(3), "days": tagged("Beaches", "Rooftop Bar", "scuba-diving")},
+			want: map[string]int{"Swimwear": 3, "Beach towel": 1, "Evening outfit": 3, "Snorkel mask": 1},
+		},
+		{
+			name:   "ignores keywords inside other words",
+			trip:   map[string]interface{}{"days": tagged("diverse food", "barong dance", "skincare")},
+			absent: []string{"Snorkel mask", "Evening outfit", "Thermal base layers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := NewPacking().Build(tt.trip)
+
+			got := make(map[string]int, len(items))
+			for _, item := range items {
+				if _, ok := got[item.Name]; ok {
+					t.Errorf("%q is listed twice", item.Name)
+				}
+				if item.Quantity < 1 {
+					t.Errorf("%q has quantity %d", item.Name, item.Quantity)
+				}
+				got[item.Name] = item.Quantity
+			}
+
+			for name, quantity := range tt.want {
+				if got[name] != quantity {
+					t.Errorf("%q quantity = %d, want %d", name, got[name], quantity)
+				}
+			}
+
+			for _, name := range tt.absent {
+				if _, ok := got[name]; ok {
+					t.Errorf("%q is listed, want it absent", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+package poi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	FormatCSV     = "csv"
+	FormatGeoJSON = "geojson"
+
+	// MinScore is the lowest score at which a place counts as a match.
+	MinScore = 0.6
+)
+
+var ErrUnknownFormat = errors.New("unknown points-of-interest format")
+
+// Place is one point of interest read from an OSM extract.
+type Place struct {
+	OsmID        string
+	Name         string
+	Category     string
+	Latitude     float64
+	Longitude    float64
+	OpeningHours string
+	Address      string
+	City         string
+}
+
+// categoryKeys are the OSM tags that say what a place is, most specific
+// first.
+var categoryKeys = []string{"tourism", "historic", "amenity", "leisure", "shop", "natural", "building"}
+
+// Read parses an extract in format. Features without a name or a position
+// are skipped and counted.
+func Read(r io.Reader, format string) ([]Place, int, error) {
+	switch format {
+	case FormatCSV:
+		return ReadCSV(r)
+	case FormatGeoJSON:
+		return ReadGeoJSON(r)
+	default:
+		return nil, 0, ErrUnknownFormat
+	}
+}
+
+// Format guesses the format from a file name.
+func Format(filename string) string {
+	lower := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
+		return FormatCSV
+	case strings.HasSuffix(lower, ".geojson"), strings.HasSuffix(lower, ".json"):
+		return FormatGeoJSON
+	default:
+		return ""
+	}
+}
+
+// ReadCSV reads a CSV export with a header row. Columns are matched by OSM
+// tag name, e.g. "name", "tourism", "opening_hours" or "addr:city", and
+// coordinates by "lat"/"latitude" and "lon"/"lng"/"longitude".
+func ReadCSV(r io.Reader) ([]Place, int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	places := make([]Place, 0)
+	skipped := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		tags := make(map[string]string, len(columns))
+		for name, i := range columns {
+			if i < len(record) {
+				tags[name] = strings.TrimSpace(record[i])
+			}
+		}
+
+		lat, lerr := strconv.ParseFloat(first(tags, "lat", "latitude", "y"), 64)
+		lon, oerr := strconv.ParseFloat(first(tags, "lon", "lng", "longitude", "x"), 64)
+		place, ok := newPlace(first(tags, "osm_id", "@id", "id"), tags, lat, lon)
+		if lerr != nil || oerr != nil || !ok {
+			skipped++
+			continue
+		}
+
+		places = append(places, place)
+	}
+
+	return places, skipped, nil
+}
+
+type featureCollection struct {
+	Features []struct {
+		ID         any            `json:"id"`
+		Geometry   *geometry      `json:"geometry"`
+		Properties map[string]any `json:"properties"`
+	} `json:"features"`
+}
+
+type geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ReadGeoJSON reads a FeatureCollection such as an Overpass export. Areas
+// are placed at the average of their outer ring.
+func ReadGeoJSON(r io.Reader) ([]Place, int, error) {
+	var collection featureCollection
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, 0, err
+	}
+
+	places := make([]Place, 0, len(collection.Features))
+	skipped := 0
+	for _, feature := range collection.Features {
+		tags := make(map[string]string, len(feature.Properties))
+		for key, value := range feature.Properties {
+			switch v := value.(type) {
+			case string:
+				tags[strings.ToLower(key)] = strings.TrimSpace(v)
+			case float64:
+				tags[strings.ToLower(key)] = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+
+		id := first(tags, "osm_id", "@id", "id")
+		switch v := feature.ID.(type) {
+		case string:
+			id = v
+		case float64:
+			id = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+
+		lon, lat, ok := center(feature.Geometry)
+		if !ok {
+			skipped++
+			continue
+		}
+
+		place, ok := newPlace(id, tags, lat, lon)
+		if !ok {
+			skipped++
+			continue
+		}
+
+		places = append(places, place)
+	}
+
+	return places, skipped, nil
+}
+
+func center(g *geometry) (float64, float64, bool) {
+	if g == nil {
+		return 0, 0, false
+	}
+
+	var points [][]float64
+	switch g.Type {
+	case "Point":
+		var point []float64
+		if err := json.Unmarshal(g.Coordinates, &point); err != nil {
+			return 0, 0, false
+		}
+		points = [][]float64{point}
+	case "LineString", "MultiPoint":
+		if err := json.Unmarshal(g.Coordinates, &points); err != nil {
+			return 0, 0, false
+		}
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &rings); err != nil || len(rings) == 0 {
+			return 0, 0, false
+		}
+		points = rings[0]
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &polygons); err != nil || len(polygons) == 0 || len(polygons[0]) == 0 {
+			return 0, 0, false
+		}
+		points = polygons[0][0]
+	default:
+		return 0, 0, false
+	}
+
+	// Rings repeat their first point at the end.
+	if n := len(points); n > 1 && slices.Equal(points[0], points[n-1]) {
+		points = points[:n-1]
+	}
+
+	var lon, lat float64
+	count := 0
+	for _, point := range points {
+		if len(point) < 2 {
+			continue
+		}
+		lon += point[0]
+		lat += point[1]
+		count++
+	}
+
+	if count == 0 {
+		return 0, 0, false
+	}
+
+	return lon / float64(count), lat / float64(count), true
+}
+
+func newPlace(id string, tags map[string]string, lat float64, lon float64) (Place, bool) {
+	name := first(tags, "name", "name:en", "int_name")
+	if name == "" || lat < -90 || lat > 90 || lon < -180 || lon > 180 || (lat == 0 && lon == 0) {
+		return Place{}, false
+	}
+
+	place := Place{
+		OsmID:        id,
+		Name:         name,
+		Category:     first(tags, "category"),
+		Latitude:     lat,
+		Longitude:    lon,
+		OpeningHours: first(tags, "opening_hours"),
+		Address:      first(tags, "address", "addr:full"),
+		City:         first(tags, "city", "addr:city"),
+	}
+
+	if place.Category == "" {
+		for _, key := range categoryKeys {
+			if value := tags[key]; value != "" && value != "yes" {
+				place.Category = key + "=" + value
+				break
+			}
+		}
+	}
+
+	if place.Address == "" {
+		place.Address = strings.TrimSpace(first(tags, "addr:street") + " " + first(tags, "addr:housenumber"))
+	}
+
+	// Extracts without OSM ids still need a stable key for re-imports.
+	if place.OsmID == "" {
+		place.OsmID = Normalize(name) + "@" + strconv.FormatFloat(lat, 'f', 5, 64) + "," + strconv.FormatFloat(lon, 'f', 5, 64)
+	}
+
+	return place, true
+}
+
+func first(tags map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := tags[key]; value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// Normalize lowercases name, drops accents and punctuation and collapses
+// spaces, so "Café de Flore" and "cafe de flore" compare equal.
+func Normalize(name string) string {
+	// Transformers keep state, so each call builds its own.
+	stripMarks := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(stripMarks, name)
+	if err != nil {
+		stripped = name
+	}
+
+	fields := strings.FieldsFunc(strings.ToLower(stripped), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(fields, " ")
+}
+
+// generic words describe a kind of place rather than name one. They count
+// for less when comparing names, so "Tanah Lot Temple" still matches
+// "Pura Tanah Lot".
+var generic = map[string]bool{
+	"the": true, "of": true, "and": true, "de": true, "la": true, "le": true, "di": true, "at": true,
+	"temple": true, "pura": true, "candi": true, "museum": true, "beach": true, "pantai": true,
+	"park": true, "taman": true, "market": true, "pasar": true, "restaurant": true, "cafe": true,
+	"warung": true, "hotel": true, "resort": true, "island": true, "pulau": true, "mount": true,
+	"gunung": true, "lake": true, "danau": true, "waterfall": true, "air": true, "terjun": true,
+	"mosque": true, "masjid": true, "church": true, "palace": true, "square": true, "street": true,
+	"jalan": true, "jl": true, "bay": true, "garden": true, "gardens": true, "national": true,
+}
+
+// Score rates from 0 to 1 how likely it is that an activity at location,
+// with the given address, takes place at place. Names are compared word by
+// word, and a city named in the address breaks ties between namesakes.
+func Score(location string, address string, place Place) float64 {
+	a := strings.Fields(Normalize(location))
+	b := strings.Fields(Normalize(place.Name))
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	weight := func(word string) float64 {
+		if generic[word] {
+			return 0.5
+		}
+		return 1
+	}
+
+	words := make(map[string]bool, len(b))
+	var total float64
+	for _, word := range b {
+		words[word] = true
+		total += weight(word)
+	}
+
+	var common float64
+	for _, word := range a {
+		total += weight(word)
+		if words[word] {
+			common += weight(word)
+			delete(words, word)
+		}
+	}
+
+	// Matching only on generic words, e.g. "Night Market", is not enough.
+	if common < 1 {
+		return 0
+	}
+
+	score := 2 * common / total
+	if city := Normalize(place.City); city != "" && strings.Contains(" "+Normalize(address)+" ", " "+city+" ") {
+		score += 0.1
+	}
+
+	return min(score, 1)
+}
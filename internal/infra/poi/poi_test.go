@@ -0,0 +1,91 @@
+package poi
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"Café de Flore":        "cafe de flore",
+		"  Pura   Tanah-Lot! ": "pura tanah lot",
+		"Warung Babi Guling 2": "warung babi guling 2",
+	}
+
+	for name, want := range tests {
+		if got := Normalize(name); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		address  string
+		place    Place
+		match    bool
+	}{
+		{
+			name:     "same name",
+			location: "Tegallalang Rice Terrace",
+			place:    Place{Name: "Tegallalang Rice Terrace"},
+			match:    true,
+		},
+		{
+			name:     "accents and case",
+			location: "cafe de flore",
+			place:    Place{Name: "Café de Flore"},
+			match:    true,
+		},
+		{
+			name:     "generic words in another language",
+			location: "Tanah Lot Temple",
+			place:    Place{Name: "Pura Tanah Lot"},
+			match:    true,
+		},
+		{
+			name:     "city in the address tips a partial match",
+			location: "Ubud Monkey Forest",
+			address:  "Jl. Monkey Forest, Ubud, Bali",
+			place:    Place{Name: "Sacred Monkey Forest Sanctuary", City: "Ubud"},
+			match:    true,
+		},
+		{
+			name:     "partial match without the city",
+			location: "Ubud Monkey Forest",
+			address:  "Bali",
+			place:    Place{Name: "Sacred Monkey Forest Sanctuary", City: "Ubud"},
+			match:    false,
+		},
+		{
+			name:     "only generic words in common",
+			location: "Night Market",
+			place:    Place{Name: "Sukawati Market"},
+			match:    false,
+		},
+		{
+			name:     "unrelated",
+			location: "Seminyak Beach",
+			place:    Place{Name: "Borobudur"},
+			match:    false,
+		},
+		{
+			name:     "empty location",
+			location: "",
+			place:    Place{Name: "Borobudur"},
+			match:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := Score(tt.location, tt.address, tt.place)
+			if score < 0 || score > 1 {
+				t.Fatalf("Score() = %v, want it between 0 and 1", score)
+			}
+
+			if got := score >= MinScore; got != tt.match {
+				t.Errorf("Score() = %v, match = %v, want %v", score, got, tt.match)
+			}
+		})
+	}
+}
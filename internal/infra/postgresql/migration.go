@@ -7,5 +7,32 @@ import (
 )
 
 func Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(entity.User{}, entity.RefreshToken{}, entity.Preference{}, entity.Trip{})
+	err := db.AutoMigrate(entity.User{}, entity.RefreshToken{}, entity.PreferenceCategory{}, entity.PreferenceTag{}, entity.Preference{}, entity.Trip{}, entity.TripRevision{}, entity.TripMessage{}, entity.GenerationJob{}, entity.AIUsage{}, entity.PromptRejection{}, entity.PackingItem{}, entity.ExchangeRate{}, entity.TripFeedback{}, entity.Place{})
+	if err != nil {
+		return err
+	}
+
+	if err := migrateFeedbackTarget(db); err != nil {
+		return err
+	}
+
+	return migratePlaceSearch(db)
+}
+
+// migrateFeedbackTarget allows one rating per user, trip revision and
+// target. Day and activity are NULL above their level, so the index compares
+// them through COALESCE, which a struct tag cannot express.
+func migrateFeedbackTarget(db *gorm.DB) error {
+	return db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_trip_feedbacks_target
+		ON trip_feedbacks (user_id, trip_id, revision, COALESCE(day, -1), COALESCE(activity, -1))`).Error
+}
+
+// migratePlaceSearch indexes place names by trigrams, which lets activities
+// be matched to the closest names without scanning every place.
+func migratePlaceSearch(db *gorm.DB) error {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return err
+	}
+
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_places_normalized_name_trgm ON places USING GIST (normalized_name gist_trgm_ops)").Error
 }
@@ -0,0 +1,282 @@
+package prompt
+
+import (
+	"apac/internal/domain/dto"
+	"apac/internal/domain/env"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+const (
+	Dir = "./resource/prompts"
+
+	TemplateTrip       = "trip"
+	TemplateRepair     = "repair"
+	TemplateRefine     = "refine"
+	TemplateRegenerate = "regenerate"
+	TemplateVariant    = "variant"
+	TemplateTranslate  = "translate"
+
+	extension = ".tmpl"
+)
+
+var required = []string{TemplateTrip, TemplateRepair, TemplateRefine, TemplateRegenerate, TemplateVariant, TemplateTranslate}
+
+// PromptItf renders model input from the templates under resource/prompts.
+// Templates live in <name>/<version>.tmpl; each name has an active version,
+// the newest one unless PROMPT_VERSIONS pins another.
+type PromptItf interface {
+	// Version returns the active version of name as "<name>/<version>",
+	// the identifier stored with trips and revisions.
+	Version(name string) string
+	Render(name string, version string, data any) (string, error)
+	Trip(req *dto.GenerateTripRequest, profile *dto.GetProfileResponse, taste *dto.TasteProfileResponse) (string, error)
+	Repair(prompt string, previous string, errs []string) (string, error)
+	Refine(current string, history []string, instruction string) (string, error)
+	Regenerate(current string, path string, instruction string) (string, error)
+	Variant(prompt string, variant string) (string, error)
+	Translate(current string, language string) (string, error)
+}
+
+type Prompt struct {
+	templates map[string]map[string]*template.Template
+	active    map[string]string
+}
+
+var funcs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"joinInts": func(values []int, sep string) string {
+		parts := make([]string, 0, len(values))
+		for _, value := range values {
+			parts = append(parts, strconv.Itoa(value))
+		}
+		return strings.Join(parts, sep)
+	},
+	"amount": func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	},
+	// language spells out a BCP 47 tag for the model, e.g. "Indonesian (id)".
+	"language": func(tag string) string {
+		parsed, err := language.Parse(tag)
+		if err != nil {
+			return tag
+		}
+
+		name := display.English.Tags().Name(parsed)
+		if name == "" {
+			return tag
+		}
+
+		return name + " (" + tag + ")"
+	},
+	// names lists taste counts by name, with reason codes such as
+	// "too_packed" spelled out.
+	"names": func(counts []dto.TasteCount) []string {
+		parts := make([]string, 0, len(counts))
+		for _, count := range counts {
+			parts = append(parts, strings.ReplaceAll(count.Name, "_", " "))
+		}
+		return parts
+	},
+	"compact": func(values ...string) []string {
+		parts := make([]string, 0, len(values))
+		for _, value := range values {
+			if value = strings.TrimSpace(value); value != "" {
+				parts = append(parts, value)
+			}
+		}
+		return parts
+	},
+}
+
+func NewPrompt(env *env.Env) (PromptItf, error) {
+	p := &Prompt{
+		templates: make(map[string]map[string]*template.Template),
+		active:    make(map[string]string),
+	}
+
+	paths, err := filepath.Glob(filepath.Join(Dir, "*", "*"+extension))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		name := filepath.Base(filepath.Dir(path))
+		version := strings.TrimSuffix(filepath.Base(path), extension)
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		tmpl, err := template.New(name + "/" + version).Funcs(funcs).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, err
+		}
+
+		if p.templates[name] == nil {
+			p.templates[name] = make(map[string]*template.Template)
+		}
+		p.templates[name][version] = tmpl
+
+		if current, ok := p.active[name]; !ok || newer(version, current) {
+			p.active[name] = version
+		}
+	}
+
+	for _, pin := range strings.Split(env.PromptVersions, ",") {
+		name, version, ok := strings.Cut(strings.TrimSpace(pin), "=")
+		if !ok {
+			continue
+		}
+
+		if _, ok := p.templates[name][version]; !ok {
+			return nil, fmt.Errorf("prompt template %s/%s does not exist", name, version)
+		}
+		p.active[name] = version
+	}
+
+	for _, name := range required {
+		if _, ok := p.active[name]; !ok {
+			return nil, fmt.Errorf("prompt template %q has no versions in %s", name, Dir)
+		}
+	}
+
+	return p, nil
+}
+
+// newer compares versions such as "v2" and "v10" numerically, falling back
+// to plain string order for other names.
+func newer(a string, b string) bool {
+	an, aerr := strconv.Atoi(strings.TrimPrefix(a, "v"))
+	bn, berr := strconv.Atoi(strings.TrimPrefix(b, "v"))
+	if aerr == nil && berr == nil {
+		return an > bn
+	}
+
+	return a > b
+}
+
+func (p *Prompt) Version(name string) string {
+	return name + "/" + p.active[name]
+}
+
+func (p *Prompt) Render(name string, version string, data any) (string, error) {
+	tmpl, ok := p.templates[name][version]
+	if !ok {
+		return "", errors.New("unknown prompt template " + name + "/" + version)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func (p *Prompt) render(name string, data any) (string, error) {
+	return p.Render(name, p.active[name], data)
+}
+
+// Trip renders a structured request into model input. profile and taste are
+// nil when the caller opted out of personalization or, for taste, when
+// there is no history yet.
+func (p *Prompt) Trip(req *dto.GenerateTripRequest, profile *dto.GetProfileResponse, taste *dto.TasteProfileResponse) (string, error) {
+	data := map[string]any{
+		"Request":  req,
+		"Profile":  profile,
+		"Taste":    taste,
+		"Likes":    []dto.PreferenceResponse{},
+		"Dislikes": []string{},
+	}
+
+	if profile != nil {
+		data["Likes"], data["Dislikes"] = splitPreferences(profile.Preferences)
+	}
+
+	return p.render(TemplateTrip, data)
+}
+
+func (p *Prompt) Repair(prompt string, previous string, errs []string) (string, error) {
+	return p.render(TemplateRepair, map[string]any{
+		"Prompt":   prompt,
+		"Previous": previous,
+		"Errors":   errs,
+	})
+}
+
+// Refine asks for an edited copy of current. history holds the user's earlier
+// instructions for the same trip, oldest first, so follow-ups such as "undo
+// that" keep their meaning.
+func (p *Prompt) Refine(current string, history []string, instruction string) (string, error) {
+	return p.render(TemplateRefine, map[string]any{
+		"Current":     current,
+		"History":     history,
+		"Instruction": instruction,
+	})
+}
+
+// Regenerate asks for a fresh alternative for the fragment at path, e.g.
+// "days[1]" or "days[1].meals.lunch", with the rest of current as context.
+func (p *Prompt) Regenerate(current string, path string, instruction string) (string, error) {
+	return p.render(TemplateRegenerate, map[string]any{
+		"Current":     current,
+		"Path":        path,
+		"Instruction": instruction,
+	})
+}
+
+// Variant steers prompt towards one direction such as "budget" or "packed".
+func (p *Prompt) Variant(prompt string, variant string) (string, error) {
+	return p.render(TemplateVariant, map[string]any{
+		"Prompt":  prompt,
+		"Variant": variant,
+	})
+}
+
+// Translate asks for current rewritten in language, with its structure,
+// times and costs left as they are.
+func (p *Prompt) Translate(current string, language string) (string, error) {
+	return p.render(TemplateTranslate, map[string]any{
+		"Current":  current,
+		"Language": language,
+	})
+}
+
+var weightRank = map[string]int{
+	"strong":   0,
+	"moderate": 1,
+	"mild":     2,
+}
+
+// splitPreferences orders likes from strongest to mildest and collects the
+// labels of the things the traveller wants to avoid.
+func splitPreferences(preferences []dto.PreferenceResponse) ([]dto.PreferenceResponse, []string) {
+	likes := make([]dto.PreferenceResponse, 0)
+	dislikes := make([]string, 0)
+	for _, pref := range preferences {
+		if pref.Polarity == "dislike" {
+			dislikes = append(dislikes, pref.Label)
+			continue
+		}
+
+		likes = append(likes, pref)
+	}
+
+	sort.SliceStable(likes, func(i, j int) bool {
+		return weightRank[likes[i].Weight] < weightRank[likes[j].Weight]
+	})
+
+	return likes, dislikes
+}
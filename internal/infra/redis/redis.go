@@ -2,6 +2,7 @@ package redis
 
 import (
 	"apac/internal/domain/env"
+	"context"
 	"time"
 
 	"github.com/gofiber/storage/redis"
@@ -11,6 +12,7 @@ type RedisItf interface {
 	Get(key string) ([]byte, error)
 	Set(key string, val []byte, exp time.Duration) error
 	Delete(key string) error
+	Incr(key string) (int64, error)
 	Reset() error
 	Close() error
 	SetOTP(email, otp string, exp time.Duration) error
@@ -50,6 +52,10 @@ func (r *Redis) Delete(key string) error {
 	return r.store.Delete(key)
 }
 
+func (r *Redis) Incr(key string) (int64, error) {
+	return r.store.Conn().Incr(context.Background(), key).Result()
+}
+
 func (r *Redis) Reset() error {
 	return r.store.Reset()
 }
@@ -60,6 +60,22 @@ func ErrEntityTooLarge(message ...string) *Err {
 	return newError(fiber.ErrRequestEntityTooLarge.Code, fiber.ErrRequestEntityTooLarge.Message, message...)
 }
 
+func ErrTooManyRequests(message ...string) *Err {
+	return newError(fiber.ErrTooManyRequests.Code, fiber.ErrTooManyRequests.Message, message...)
+}
+
+func ErrBadGateway(message ...string) *Err {
+	return newError(fiber.ErrBadGateway.Code, fiber.ErrBadGateway.Message, message...)
+}
+
+func ErrServiceUnavailable(message ...string) *Err {
+	return newError(fiber.ErrServiceUnavailable.Code, fiber.ErrServiceUnavailable.Message, message...)
+}
+
+func ErrGatewayTimeout(message ...string) *Err {
+	return newError(fiber.ErrGatewayTimeout.Code, fiber.ErrGatewayTimeout.Message, message...)
+}
+
 func respondWithError(ctx *fiber.Ctx, code int, defaultMsg string, message ...string) error {
 	msg := defaultMsg
 	if len(message) == 1 {
@@ -0,0 +1,20 @@
+package response
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+)
+
+func WriteEvent(w *bufio.Writer, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,175 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+type SchemaItf interface {
+	Validate(trip map[string]interface{}) []string
+}
+
+// Schema checks generated trips against resource/schema.json and against
+// the rules the schema cannot express, such as the day count matching the
+// duration.
+type Schema struct {
+	root map[string]interface{}
+}
+
+func NewSchema(path string) (SchemaItf, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var root map[string]interface{}
+	if err := json.Unmarshal(content, &root); err != nil {
+		return nil, err
+	}
+
+	return &Schema{root: root}, nil
+}
+
+func (s *Schema) Validate(trip map[string]interface{}) []string {
+	errs := validate(s.root, trip, "$")
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return semantic(trip)
+}
+
+// validate supports the JSON Schema keywords used by the trip schema: type,
+// properties, required, items and the date-time format.
+func validate(node map[string]interface{}, value interface{}, path string) []string {
+	errs := make([]string, 0)
+
+	switch node["type"] {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return append(errs, path+": must be an object")
+		}
+
+		if required, ok := node["required"].([]interface{}); ok {
+			for _, name := range required {
+				key, _ := name.(string)
+				if v, ok := obj[key]; !ok || v == nil {
+					errs = append(errs, path+"."+key+": is required")
+				}
+			}
+		}
+
+		properties, _ := node["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(properties))
+		for key := range properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			v, ok := obj[key]
+			if !ok || v == nil {
+				continue
+			}
+
+			if child, ok := properties[key].(map[string]interface{}); ok {
+				errs = append(errs, validate(child, v, path+"."+key)...)
+			}
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			return append(errs, path+": must be an array")
+		}
+
+		if items, ok := node["items"].(map[string]interface{}); ok {
+			for i, item := range arr {
+				errs = append(errs, validate(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return append(errs, path+": must be a string")
+		}
+
+		if strings.TrimSpace(str) == "" || strings.EqualFold(str, "N/A") {
+			errs = append(errs, path+": must not be empty or N/A")
+		}
+
+		if node["format"] == "date-time" {
+			if _, err := ParseDate(str); err != nil {
+				errs = append(errs, path+": must be a date-time")
+			}
+		}
+	case "integer":
+		num, ok := value.(float64)
+		if !ok || num != math.Trunc(num) {
+			return append(errs, path+": must be an integer")
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return append(errs, path+": must be a number")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return append(errs, path+": must be a boolean")
+		}
+	}
+
+	return errs
+}
+
+func semantic(trip map[string]interface{}) []string {
+	errs := make([]string, 0)
+
+	if travelers, _ := trip["travelers"].(float64); travelers < 1 {
+		errs = append(errs, "$.travelers: must be at least 1")
+	}
+
+	start, _ := ParseDate(trip["startDate"].(string))
+	end, _ := ParseDate(trip["endDate"].(string))
+	if end.Before(start) {
+		errs = append(errs, "$.endDate: must not be before startDate")
+	}
+
+	days, _ := trip["days"].([]interface{})
+	duration, _ := trip["duration"].(float64)
+	if len(days) != int(duration) {
+		errs = append(errs, fmt.Sprintf("$.days: has %d entries but duration is %d", len(days), int(duration)))
+	}
+
+	for i, d := range days {
+		day, _ := d.(map[string]interface{})
+		path := fmt.Sprintf("$.days[%d]", i)
+
+		if number, _ := day["day"].(float64); int(number) != i+1 {
+			errs = append(errs, fmt.Sprintf("%s.day: must be %d", path, i+1))
+		}
+
+		date, err := ParseDate(day["date"].(string))
+		if err == nil && (date.Before(start) || date.After(end)) {
+			errs = append(errs, path+".date: must fall between startDate and endDate")
+		}
+	}
+
+	return errs
+}
+
+// ParseDate accepts RFC 3339 timestamps and plain dates, and returns the
+// calendar day in UTC.
+func ParseDate(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
@@ -0,0 +1,138 @@
+package schema
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+const schemaPath = "../../../resource/schema.json"
+
+const validTrip = `{
+	"title": "Bali Getaway",
+	"destination": "Bali",
+	"startDate": "2030-01-14",
+	"endDate": "2030-01-15",
+	"duration": 2,
+	"travelers": 2,
+	"budget": "IDR 5,000,000",
+	"summary": "Beaches and temples",
+	"totalCost": "IDR 4,200,000",
+	"days": [
+		{
+			"day": 1,
+			"date": "2030-01-14",
+			"title": "Arrival",
+			"description": "Settle in by the sea",
+			"activities": [
+				{"time": "16:00", "title": "Sunset", "description": "Watch the sunset", "location": "Tanah Lot", "address": "Beraban, Tabanan", "cost": "IDR 60,000", "tags": ["culture"]}
+			],
+			"accommodation": {"name": "Seaside Inn", "address": "Jl. Pantai, Kuta", "checkIn": "14:00", "checkOut": "12:00", "cost": "IDR 900,000"},
+			"meals": {},
+			"transportation": {"mode": "Taxi", "details": "Airport to hotel", "departureTime": "12:00", "arrivalTime": "13:00", "cost": "IDR 200,000"}
+		},
+		{
+			"day": 2,
+			"date": "2030-01-15",
+			"title": "Ubud",
+			"description": "Rice terraces",
+			"activities": [
+				{"time": "09:00", "title": "Terraces", "description": "Walk the terraces", "location": "Tegallalang", "address": "Tegallalang, Gianyar", "cost": "IDR 50,000", "tags": ["nature"]}
+			],
+			"accommodation": {"name": "Seaside Inn", "address": "Jl. Pantai, Kuta", "checkIn": "14:00", "checkOut": "12:00", "cost": "IDR 900,000"},
+			"meals": {},
+			"transportation": {"mode": "Car", "details": "Day driver", "departureTime": "08:00", "arrivalTime": "09:00", "cost": "IDR 600,000"}
+		}
+	]
+}`
+
+func TestValidate(t *testing.T) {
+	validator, err := NewSchema(schemaPath)
+	if err != nil {
+		t.Fatalf("NewSchema() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(trip map[string]interface{})
+		want   []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(trip map[string]interface{}) {},
+		},
+		{
+			name:   "missing field",
+			mutate: func(trip map[string]interface{}) { delete(trip, "title") },
+			want:   []string{"$.title: is required"},
+		},
+		{
+			name:   "wrong type",
+			mutate: func(trip map[string]interface{}) { trip["travelers"] = "two" },
+			want:   []string{"$.travelers: must be an integer"},
+		},
+		{
+			name:   "placeholder text",
+			mutate: func(trip map[string]interface{}) { trip["summary"] = "N/A" },
+			want:   []string{"$.summary: must not be empty or N/A"},
+		},
+		{
+			name:   "no travelers",
+			mutate: func(trip map[string]interface{}) { trip["travelers"] = float64(0) },
+			want:   []string{"$.travelers: must be at least 1"},
+		},
+		{
+			name:   "day count differs from duration",
+			mutate: func(trip map[string]interface{}) { trip["duration"] = float64(3) },
+			want:   []string{"$.days: has 2 entries but duration is 3"},
+		},
+		{
+			name: "days out of order",
+			mutate: func(trip map[string]interface{}) {
+				trip["days"].([]interface{})[1].(map[string]interface{})["day"] = float64(3)
+			},
+			want: []string{"$.days[1].day: must be 2"},
+		},
+		{
+			name:   "end before start",
+			mutate: func(trip map[string]interface{}) { trip["endDate"] = "2000-01-01" },
+			want:   []string{"$.endDate: must not be before startDate"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var trip map[string]interface{}
+			if err := json.Unmarshal([]byte(validTrip), &trip); err != nil {
+				t.Fatalf("invalid fixture: %v", err)
+			}
+			tt.mutate(trip)
+
+			got := validator.Validate(trip)
+			if len(tt.want) == 0 && len(got) > 0 {
+				t.Errorf("Validate() = %q, want no errors", got)
+			}
+
+			for _, want := range tt.want {
+				if !slices.Contains(got, want) {
+					t.Errorf("Validate() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2030, time.January, 14, 0, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{"2030-01-14", "2030-01-14T09:30:00", "2030-01-14T09:30:00+07:00"} {
+		if got, err := ParseDate(value); err != nil || !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+
+	if _, err := ParseDate("14/01/2030"); err == nil {
+		t.Error("ParseDate() accepted an unsupported layout")
+	}
+}
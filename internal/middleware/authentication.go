@@ -24,14 +24,15 @@ func (m *Middleware) Authentication(ctx *fiber.Ctx) error {
 		return res.Unauthorized(ctx, "Wrong authorization type")
 	}
 
-	userID, name, email, err := m.jwt.VerifyAccessToken(token[1])
+	claims, err := m.jwt.VerifyAccessToken(token[1])
 	if err != nil {
 		return res.Unauthorized(ctx, err.Error())
 	}
 
-	ctx.Locals("userID", userID)
-	ctx.Locals("name", name)
-	ctx.Locals("email", email)
+	ctx.Locals("userID", claims.UserID)
+	ctx.Locals("name", claims.Name)
+	ctx.Locals("email", claims.Email)
+	ctx.Locals("role", claims.Role)
 
 	return ctx.Next()
 }
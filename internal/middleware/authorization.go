@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"apac/internal/domain/entity"
+	res "apac/internal/infra/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (m *Middleware) Admin(ctx *fiber.Ctx) error {
+	role, _ := ctx.Locals("role").(string)
+	if role != entity.RoleAdmin {
+		return res.Forbidden(ctx, "Admin access required")
+	}
+
+	return ctx.Next()
+}
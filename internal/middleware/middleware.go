@@ -8,6 +8,7 @@ import (
 
 type MiddlewareItf interface {
 	Authentication(*fiber.Ctx) error
+	Admin(*fiber.Ctx) error
 }
 
 type Middleware struct {